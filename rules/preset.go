@@ -4,25 +4,44 @@ import "github.com/terraform-linters/tflint-plugin-sdk/tflint"
 
 var PresetRules = map[string][]tflint.Rule{
 	"all": {
+		NewTerraformArgumentOrderRule(),
 		NewTerraformCommentSyntaxRule(),
+		NewTerraformComputedCountForEachRule(),
+		NewTerraformDeprecatedFunctionsRule(),
 		NewTerraformDeprecatedIndexRule(),
 		NewTerraformDeprecatedInterpolationRule(),
 		NewTerraformDeprecatedLookupRule(),
 		NewTerraformDocumentedOutputsRule(),
 		NewTerraformDocumentedVariablesRule(),
 		NewTerraformEmptyListEqualityRule(),
+		NewTerraformFilePathsRule(),
+		NewTerraformFmtRule(),
+		NewTerraformForbiddenTypesRule(),
+		NewTerraformFunctionCallsRule(),
+		NewTerraformHardcodedSecretsRule(),
 		NewTerraformJSONSyntaxRule(),
+		NewTerraformLifecycleRule(),
+		NewTerraformLockFileRule(),
 		NewTerraformMapDuplicateKeysRule(),
+		NewTerraformMetaArgumentsRule(),
+		NewTerraformModuleAllowedSourcesRule(),
 		NewTerraformModulePinnedSourceRule(),
 		NewTerraformModuleShallowCloneRule(),
 		NewTerraformModuleVersionRule(),
 		NewTerraformNamingConventionRule(),
+		NewTerraformProviderConstraintsRule(),
+		NewTerraformQuotedReferencesRule(),
+		NewTerraformRedundantDependsOnRule(),
+		NewTerraformRequiredAttributesRule(),
 		NewTerraformRequiredProvidersRule(),
 		NewTerraformRequiredVersionRule(),
+		NewTerraformSensitiveOutputsRule(),
 		NewTerraformStandardModuleStructureRule(),
+		NewTerraformTemplatefileVarsRule(),
 		NewTerraformTypedVariablesRule(),
 		NewTerraformUnusedDeclarationsRule(),
 		NewTerraformUnusedRequiredProvidersRule(),
+		NewTerraformWhitespaceRule(),
 		NewTerraformWorkspaceRemoteRule(),
 	},
 	"recommended": {
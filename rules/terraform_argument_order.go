@@ -0,0 +1,285 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// TerraformArgumentOrderRule checks whether arguments and nested blocks are declared in the canonical order
+type TerraformArgumentOrderRule struct {
+	tflint.DefaultRule
+}
+
+type terraformArgumentOrderRuleConfig struct {
+	Resource *ArgumentOrderConfig `hclext:"resource,block"`
+	Data     *ArgumentOrderConfig `hclext:"data,block"`
+	Module   *ArgumentOrderConfig `hclext:"module,block"`
+	Variable *ArgumentOrderConfig `hclext:"variable,block"`
+	Output   *ArgumentOrderConfig `hclext:"output,block"`
+}
+
+// ArgumentOrderConfig defines the arguments and nested blocks to be declared first and last in a block.
+// Anything else is placed between them, with arguments before nested blocks.
+type ArgumentOrderConfig struct {
+	First []string `hclext:"first,optional"`
+	Last  []string `hclext:"last,optional"`
+}
+
+// bodyItem is an argument or a nested block in a block body
+type bodyItem struct {
+	name    string
+	isBlock bool
+	rng     hcl.Range
+	subject hcl.Range
+}
+
+// NewTerraformArgumentOrderRule returns a new rule
+func NewTerraformArgumentOrderRule() *TerraformArgumentOrderRule {
+	return &TerraformArgumentOrderRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformArgumentOrderRule) Name() string {
+	return "terraform_argument_order"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformArgumentOrderRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformArgumentOrderRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformArgumentOrderRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether arguments and nested blocks follow the configured order
+func (r *TerraformArgumentOrderRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	// @see https://developer.hashicorp.com/terraform/language/style#resource-order
+	config := terraformArgumentOrderRuleConfig{
+		Resource: &ArgumentOrderConfig{
+			First: []string{"count", "for_each", "provider"},
+			Last:  []string{"lifecycle", "depends_on"},
+		},
+		Data: &ArgumentOrderConfig{
+			First: []string{"count", "for_each", "provider"},
+			Last:  []string{"lifecycle", "depends_on"},
+		},
+		Module: &ArgumentOrderConfig{
+			First: []string{"source", "version", "count", "for_each", "providers"},
+			Last:  []string{"depends_on"},
+		},
+		Variable: &ArgumentOrderConfig{
+			First: []string{"type", "description", "default", "sensitive", "nullable", "validation"},
+		},
+	}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	orders := map[string]*ArgumentOrderConfig{
+		"resource": config.Resource,
+		"data":     config.Data,
+		"module":   config.Module,
+		"variable": config.Variable,
+		"output":   config.Output,
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for name, file := range files {
+		if err := r.checkFile(runner, name, file, orders); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformArgumentOrderRule) checkFile(runner tflint.Runner, filename string, file *hcl.File, orders map[string]*ArgumentOrderConfig) error {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		// JSON objects have no meaningful order.
+		return nil
+	}
+
+	for _, block := range body.Blocks {
+		order := orders[block.Type]
+		if order == nil {
+			continue
+		}
+		if err := r.checkBlock(runner, file.Bytes, block, order); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformArgumentOrderRule) checkBlock(runner tflint.Runner, src []byte, block *hclsyntax.Block, order *ArgumentOrderConfig) error {
+	items := []*bodyItem{}
+	for _, attr := range block.Body.Attributes {
+		items = append(items, &bodyItem{name: attr.Name, rng: attr.SrcRange, subject: attr.NameRange})
+	}
+	for _, nested := range block.Body.Blocks {
+		items = append(items, &bodyItem{name: nested.Type, isBlock: true, rng: nested.Range(), subject: nested.DefRange()})
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].rng.Start.Byte < items[j].rng.Start.Byte })
+
+	expected := slices.Clone(items)
+	sort.SliceStable(expected, func(i, j int) bool { return order.rank(expected[i]) < order.rank(expected[j]) })
+
+	for i := range items {
+		if items[i] == expected[i] {
+			continue
+		}
+
+		return runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("`%s` should be declared before `%s` in %s", expected[i].name, items[i].name, blockAddress(block)),
+			expected[i].subject,
+			func(f tflint.Fixer) error {
+				rng, text, ok := reorderBodyItems(src, block, items, expected)
+				if !ok {
+					return tflint.ErrFixNotSupported
+				}
+				return f.ReplaceText(rng, text)
+			},
+		)
+	}
+
+	return nil
+}
+
+// rank returns the sort key of the given item.
+// Arguments listed in "first" come first, followed by other arguments,
+// nested blocks listed in "first", other nested blocks, and finally items listed in "last".
+// Arguments are always placed before nested blocks, so arguments missing from "first",
+// such as new meta-arguments, do not have to follow nested blocks listed there.
+func (c *ArgumentOrderConfig) rank(item *bodyItem) int {
+	if idx := slices.Index(c.First, item.name); idx != -1 {
+		if item.isBlock {
+			return len(c.First) + 1 + idx
+		}
+		return idx
+	}
+	if idx := slices.Index(c.Last, item.name); idx != -1 {
+		return 2*len(c.First) + 2 + idx
+	}
+	if item.isBlock {
+		return 2*len(c.First) + 1
+	}
+	return len(c.First)
+}
+
+// reorderBodyItems returns the range of the items in the block body and
+// the text to replace it with, in which the items are in the expected order.
+//
+// Each item is moved together with the comments directly above it and
+// a comment at the end of its last line. Blank lines stay where they are,
+// so the grouping of the body is preserved. If the items cannot be split
+// into separate lines, it returns false.
+func reorderBodyItems(src []byte, block *hclsyntax.Block, items []*bodyItem, expected []*bodyItem) (hcl.Range, string, bool) {
+	type chunk struct{ start, end int }
+
+	chunks := map[*bodyItem]chunk{}
+	seps := []string{}
+
+	prevEnd := lineEnd(src, block.OpenBraceRange.End.Byte)
+	if prevEnd > block.CloseBraceRange.Start.Byte {
+		// The first item is on the same line as the opening brace.
+		return hcl.Range{}, "", false
+	}
+	bodyStart := prevEnd
+
+	for _, item := range items {
+		start := lineStart(src, item.rng.Start.Byte)
+		if start < prevEnd {
+			// The item shares a line with the previous one.
+			return hcl.Range{}, "", false
+		}
+		for start > prevEnd {
+			above := lineStart(src, start-1)
+			if len(bytes.TrimSpace(src[above:start])) == 0 {
+				break
+			}
+			start = above
+		}
+		end := lineEnd(src, item.rng.End.Byte)
+		if end > block.CloseBraceRange.Start.Byte {
+			// The last item is on the same line as the closing brace.
+			return hcl.Range{}, "", false
+		}
+
+		seps = append(seps, string(src[prevEnd:start]))
+		chunks[item] = chunk{start: start, end: end}
+		prevEnd = end
+	}
+
+	var text strings.Builder
+	for i, item := range expected {
+		text.WriteString(seps[i])
+		c := chunks[item]
+		text.Write(src[c.start:c.end])
+	}
+
+	rng := hcl.Range{
+		Filename: block.OpenBraceRange.Filename,
+		Start:    posAt(src, bodyStart),
+		End:      posAt(src, prevEnd),
+	}
+	return rng, text.String(), true
+}
+
+// blockAddress returns a human-readable address like `resource "aws_instance" "web"`
+func blockAddress(block *hclsyntax.Block) string {
+	parts := []string{block.Type}
+	for _, label := range block.Labels {
+		parts = append(parts, fmt.Sprintf("%q", label))
+	}
+	return strings.Join(parts, " ")
+}
+
+// lineStart returns the byte offset of the beginning of the line containing the given offset
+func lineStart(src []byte, offset int) int {
+	return bytes.LastIndexByte(src[:offset], '\n') + 1
+}
+
+// lineEnd returns the byte offset just after the newline of the line containing the given offset
+func lineEnd(src []byte, offset int) int {
+	idx := bytes.IndexByte(src[offset:], '\n')
+	if idx == -1 {
+		return len(src)
+	}
+	return offset + idx + 1
+}
+
+// posAt returns the hcl.Pos of the given byte offset
+func posAt(src []byte, offset int) hcl.Pos {
+	line := bytes.Count(src[:offset], []byte("\n")) + 1
+	column := offset - lineStart(src, offset) + 1
+	return hcl.Pos{Line: line, Column: column, Byte: offset}
+}
@@ -0,0 +1,278 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformArgumentOrderRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Config   string
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "canonical order",
+			Content: `
+resource "aws_instance" "web" {
+  count    = 2
+  provider = aws.west
+
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+
+  network_interface {
+    device_index = 0
+  }
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  depends_on = [aws_vpc.main]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "meta-argument after arguments",
+			Content: `
+resource "aws_instance" "web" {
+  # The AMI
+  ami   = "ami-12345678" # pinned
+  count = 2
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`count` should be declared before `ami` in resource \"aws_instance\" \"web\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 8},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  count = 2
+  # The AMI
+  ami = "ami-12345678" # pinned
+}`,
+		},
+		{
+			Name: "blank lines are preserved",
+			Content: `
+resource "aws_instance" "web" {
+  depends_on = [aws_vpc.main]
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  ami = "ami-12345678"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`ami` should be declared before `depends_on` in resource \"aws_instance\" \"web\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 3},
+						End:      hcl.Pos{Line: 9, Column: 6},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  ami = "ami-12345678"
+
+  lifecycle {
+    create_before_destroy = true
+  }
+
+  depends_on = [aws_vpc.main]
+}`,
+		},
+		{
+			Name: "nested block before arguments",
+			Content: `
+data "aws_ami" "ubuntu" {
+  filter {
+    name   = "name"
+    values = ["ubuntu-*"]
+  }
+  most_recent = true
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`most_recent` should be declared before `filter` in data \"aws_ami\" \"ubuntu\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 3},
+						End:      hcl.Pos{Line: 7, Column: 14},
+					},
+				},
+			},
+			Fixed: `
+data "aws_ami" "ubuntu" {
+  most_recent = true
+  filter {
+    name   = "name"
+    values = ["ubuntu-*"]
+  }
+}`,
+		},
+		{
+			Name: "module",
+			Content: `
+module "vpc" {
+  cidr    = "10.0.0.0/16"
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`source` should be declared before `cidr` in module \"vpc\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 9},
+					},
+				},
+			},
+			Fixed: `
+module "vpc" {
+  source  = "terraform-aws-modules/vpc/aws"
+  version = "5.0.0"
+  cidr    = "10.0.0.0/16"
+}`,
+		},
+		{
+			Name: "variable",
+			Content: `
+variable "region" {
+  description = "The region"
+  default     = "us-east-1"
+  type        = string
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`type` should be declared before `description` in variable \"region\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 7},
+					},
+				},
+			},
+			Fixed: `
+variable "region" {
+  type        = string
+  description = "The region"
+  default     = "us-east-1"
+}`,
+		},
+		{
+			Name: "variable with arguments missing from the order",
+			Content: `
+variable "token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+
+  validation {
+    condition     = length(var.token) > 0
+    error_message = "The token must not be empty."
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "custom order",
+			Config: `
+rule "terraform_argument_order" {
+  enabled = true
+
+  output {
+    first = ["description", "value"]
+    last  = ["depends_on"]
+  }
+}`,
+			Content: `
+output "id" {
+  value       = aws_instance.web.id
+  description = "The ID"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformArgumentOrderRule(),
+					Message: "`description` should be declared before `value` in output \"id\"",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 14},
+					},
+				},
+			},
+			Fixed: `
+output "id" {
+  description = "The ID"
+  value       = aws_instance.web.id
+}`,
+		},
+		{
+			Name: "single line block",
+			Content: `
+resource "null_resource" "a" { count = 1 }`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "ami": "ami-12345678",
+        "count": 2
+      }
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformArgumentOrderRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
@@ -0,0 +1,381 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/lang"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// applyTimeFunctions are functions that return a different value on every run,
+// so their results are unknown until apply
+var applyTimeFunctions = map[string]bool{
+	"timestamp": true,
+	"uuid":      true,
+}
+
+// TerraformComputedCountForEachRule checks whether count and for_each depend on values unknown until apply
+type TerraformComputedCountForEachRule struct {
+	tflint.DefaultRule
+}
+
+type terraformComputedCountForEachRuleConfig struct {
+	ComputedAttributes []string `hclext:"computed_attributes,optional"`
+}
+
+// NewTerraformComputedCountForEachRule returns a new rule
+func NewTerraformComputedCountForEachRule() *TerraformComputedCountForEachRule {
+	return &TerraformComputedCountForEachRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformComputedCountForEachRule) Name() string {
+	return "terraform_computed_count_for_each"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformComputedCountForEachRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformComputedCountForEachRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformComputedCountForEachRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check traces count and for_each through locals and emits issues if they depend on
+// attributes of managed resources or functions whose values are unknown until apply
+func (r *TerraformComputedCountForEachRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformComputedCountForEachRuleConfig{ComputedAttributes: []string{"id", "arn"}}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+
+	repetitionSchema := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "count"},
+			{Name: "for_each"},
+		},
+	}
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: repetitionSchema},
+			{Type: "data", LabelNames: []string{"type", "name"}, Body: repetitionSchema},
+			{Type: "ephemeral", LabelNames: []string{"type", "name"}, Body: repetitionSchema},
+			{Type: "module", LabelNames: []string{"name"}, Body: repetitionSchema},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	locals, diags := runner.GetLocals()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	tracer := &computedValueTracer{
+		resources:  map[string]bool{},
+		attributes: map[string]bool{},
+		locals:     locals,
+	}
+	for _, block := range body.Blocks.OfType("resource") {
+		tracer.resources[fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])] = true
+	}
+	for _, attr := range config.ComputedAttributes {
+		tracer.attributes[attr] = true
+	}
+
+	for _, block := range body.Blocks {
+		if attr, exists := block.Body.Attributes["count"]; exists {
+			if chain := tracer.traceCount(attr.Expr, map[string]bool{}); chain != nil {
+				if err := r.emitIssue(runner, "count", chain, attr.Expr.Range()); err != nil {
+					return err
+				}
+			}
+		}
+		if attr, exists := block.Body.Attributes["for_each"]; exists {
+			if chain := tracer.traceKeys(attr.Expr, map[string]bool{}); chain != nil {
+				if err := r.emitIssue(runner, "for_each", chain, attr.Expr.Range()); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformComputedCountForEachRule) emitIssue(runner tflint.Runner, name string, chain []string, rng hcl.Range) error {
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("%s depends on a value that is unknown until apply: %s", name, strings.Join(chain, " -> ")),
+		rng,
+	)
+}
+
+// computedValueTracer follows references through locals to values that are unknown until apply
+type computedValueTracer struct {
+	resources  map[string]bool
+	attributes map[string]bool
+	locals     map[string]*terraform.Local
+}
+
+// traceKeys returns the chain of references to an unknown value that determines keys of a for_each value,
+// or nil if the keys are known during plan. Only keys matter for for_each, so unknown map values are allowed.
+func (t *computedValueTracer) traceKeys(expr hcl.Expression, visited map[string]bool) []string {
+	switch e := expr.(type) {
+	case *hclsyntax.ScopeTraversalExpr:
+		if name, ok := localName(e.Traversal); ok {
+			return t.traceLocal(name, visited, t.traceKeys)
+		}
+	case *hclsyntax.ForExpr:
+		if e.KeyExpr == nil {
+			break
+		}
+		exprs := []hclsyntax.Expression{e.KeyExpr}
+		if e.CondExpr != nil {
+			exprs = append(exprs, e.CondExpr)
+		}
+		if chain := t.traceForExpr(e, exprs, visited); chain != nil {
+			return chain
+		}
+		if e.KeyVar != "" && referencesVariable(e.KeyExpr, e.KeyVar) {
+			// Keys are derived from the keys of the collection.
+			return t.traceKeys(e.CollExpr, visited)
+		}
+		return nil
+	case *hclsyntax.ObjectConsExpr:
+		for _, item := range e.Items {
+			if chain := t.traceAll(item.KeyExpr, visited); chain != nil {
+				return chain
+			}
+		}
+		return nil
+	case *hclsyntax.FunctionCallExpr:
+		switch e.Name {
+		case "toset":
+			// Elements of a set are used as keys.
+			for _, arg := range e.Args {
+				if chain := t.traceAll(arg, visited); chain != nil {
+					return chain
+				}
+			}
+			return nil
+		case "tomap", "merge":
+			for _, arg := range e.Args {
+				if chain := t.traceKeys(arg, visited); chain != nil {
+					return chain
+				}
+			}
+			return nil
+		}
+	}
+
+	return t.traceAll(expr, visited)
+}
+
+// traceCount returns the chain of references to an unknown value that determines a count value,
+// or nil if the value is known during plan. The length of a list is known even if its elements are unknown.
+func (t *computedValueTracer) traceCount(expr hcl.Expression, visited map[string]bool) []string {
+	if call, ok := expr.(*hclsyntax.FunctionCallExpr); ok && call.Name == "length" && len(call.Args) == 1 {
+		switch arg := call.Args[0].(type) {
+		case *hclsyntax.SplatExpr:
+			return t.traceAll(arg.Source, visited)
+		case *hclsyntax.ForExpr:
+			if arg.CondExpr == nil {
+				return t.traceAll(arg.CollExpr, visited)
+			}
+		}
+	}
+
+	return t.traceAll(expr, visited)
+}
+
+// traceAll returns the chain of references to an unknown value in the expression
+func (t *computedValueTracer) traceAll(expr hcl.Expression, visited map[string]bool) []string {
+	var chain []string
+
+	if node, ok := expr.(hclsyntax.Node); ok {
+		hclsyntax.VisitAll(node, func(n hclsyntax.Node) hcl.Diagnostics {
+			if chain != nil {
+				return nil
+			}
+			switch e := n.(type) {
+			case *hclsyntax.FunctionCallExpr:
+				if applyTimeFunctions[e.Name] {
+					chain = []string{e.Name + "()"}
+				}
+			case *hclsyntax.ForExpr:
+				exprs := []hclsyntax.Expression{e.ValExpr}
+				if e.KeyExpr != nil {
+					exprs = append(exprs, e.KeyExpr)
+				}
+				if e.CondExpr != nil {
+					exprs = append(exprs, e.CondExpr)
+				}
+				chain = t.traceIterator(e, exprs)
+			case *hclsyntax.SplatExpr:
+				resource, ok := t.resourceTraversal(e.Source)
+				if !ok {
+					return nil
+				}
+				if each, ok := e.Each.(*hclsyntax.RelativeTraversalExpr); ok {
+					if attr := t.computedAttribute(each.Traversal); attr != "" {
+						chain = []string{fmt.Sprintf("%s[*].%s", resource, attr)}
+					}
+				}
+			}
+			return nil
+		})
+		if chain != nil {
+			return chain
+		}
+	}
+
+	for _, ref := range lang.ReferencesInExpr(expr) {
+		switch sub := ref.Subject.(type) {
+		case addrs.Resource:
+			if attr := t.computedAttribute(ref.Remaining); attr != "" && t.isManagedResource(sub) {
+				return []string{fmt.Sprintf("%s.%s", sub, attr)}
+			}
+		case addrs.ResourceInstance:
+			if attr := t.computedAttribute(ref.Remaining); attr != "" && t.isManagedResource(sub.Resource) {
+				return []string{fmt.Sprintf("%s.%s", sub, attr)}
+			}
+		case addrs.LocalValue:
+			if chain := t.traceLocal(sub.Name, visited, t.traceAll); chain != nil {
+				return chain
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *computedValueTracer) traceLocal(name string, visited map[string]bool, trace func(hcl.Expression, map[string]bool) []string) []string {
+	if visited[name] {
+		return nil
+	}
+	visited[name] = true
+
+	local, exists := t.locals[name]
+	if !exists {
+		return nil
+	}
+	if chain := trace(local.Attribute.Expr, visited); chain != nil {
+		return append([]string{"local." + name}, chain...)
+	}
+	return nil
+}
+
+// traceForExpr returns the chain of references to an unknown value in the given parts of the for expression
+func (t *computedValueTracer) traceForExpr(e *hclsyntax.ForExpr, exprs []hclsyntax.Expression, visited map[string]bool) []string {
+	if chain := t.traceIterator(e, exprs); chain != nil {
+		return chain
+	}
+	for _, expr := range exprs {
+		if chain := t.traceAll(expr, visited); chain != nil {
+			return chain
+		}
+	}
+	return nil
+}
+
+// traceIterator returns the chain if the given parts of the for expression refer to an unknown attribute
+// of a resource through the iterator, such as `[for s in aws_subnet.main : s.id]`
+func (t *computedValueTracer) traceIterator(e *hclsyntax.ForExpr, exprs []hclsyntax.Expression) []string {
+	resource, ok := t.resourceTraversal(e.CollExpr)
+	if !ok {
+		return nil
+	}
+
+	for _, expr := range exprs {
+		for _, traversal := range expr.Variables() {
+			if traversal.RootName() != e.ValVar {
+				continue
+			}
+			if attr := t.computedAttribute(traversal[1:]); attr != "" {
+				return []string{fmt.Sprintf("%s[*].%s", resource, attr)}
+			}
+		}
+	}
+	return nil
+}
+
+// resourceTraversal returns the address if the expression refers to a whole managed resource in the module
+func (t *computedValueTracer) resourceTraversal(expr hclsyntax.Expression) (string, bool) {
+	traversal, ok := expr.(*hclsyntax.ScopeTraversalExpr)
+	if !ok {
+		return "", false
+	}
+	ref, diags := addrs.ParseRef(traversal.Traversal)
+	if diags.HasErrors() || len(ref.Remaining) > 0 {
+		return "", false
+	}
+	resource, ok := ref.Subject.(addrs.Resource)
+	if !ok || !t.isManagedResource(resource) {
+		return "", false
+	}
+	return resource.String(), true
+}
+
+func (t *computedValueTracer) isManagedResource(resource addrs.Resource) bool {
+	return resource.Mode == addrs.ManagedResourceMode && t.resources[resource.String()]
+}
+
+// computedAttribute returns the attribute name if the traversal starts with an attribute unknown until apply
+func (t *computedValueTracer) computedAttribute(traversal hcl.Traversal) string {
+	if len(traversal) == 0 {
+		return ""
+	}
+	attr, ok := traversal[0].(hcl.TraverseAttr)
+	if !ok || !t.attributes[attr.Name] {
+		return ""
+	}
+	return attr.Name
+}
+
+// localName returns the name if the traversal refers to a whole local value
+func localName(traversal hcl.Traversal) (string, bool) {
+	if len(traversal) != 2 || traversal.RootName() != "local" {
+		return "", false
+	}
+	attr, ok := traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
+
+func referencesVariable(expr hclsyntax.Expression, name string) bool {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() == name {
+			return true
+		}
+	}
+	return false
+}
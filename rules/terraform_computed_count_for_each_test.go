@@ -0,0 +1,160 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformComputedCountForEachRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "known values",
+			Content: `
+resource "aws_subnet" "main" {
+  for_each = var.subnets
+}
+
+locals {
+  subnet_ids = { for name, subnet in aws_subnet.main : name => subnet.id }
+}
+
+resource "aws_route_table_association" "main" {
+  for_each  = local.subnet_ids
+  subnet_id = each.value
+}
+
+resource "aws_eip" "main" {
+  count = length(aws_subnet.main[*].id)
+}
+
+resource "aws_instance" "main" {
+  for_each = toset(var.names)
+  count    = length(data.aws_subnets.main.ids)
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "keys from resource attributes",
+			Content: `
+resource "aws_subnet" "main" {
+  count = 2
+}
+
+resource "aws_security_group" "main" {}
+
+locals {
+  subnet_ids = [for subnet in aws_subnet.main : subnet.id]
+  by_id      = { for subnet in aws_subnet.main : subnet.id => subnet }
+}
+
+resource "aws_route_table_association" "main" {
+  for_each = toset(local.subnet_ids)
+}
+
+resource "aws_network_interface" "main" {
+  for_each = local.by_id
+}
+
+resource "aws_instance" "main" {
+  count = aws_security_group.main.id != "" ? 1 : 0
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformComputedCountForEachRule(),
+					Message: "for_each depends on a value that is unknown until apply: local.subnet_ids -> aws_subnet.main[*].id",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 14},
+						End:      hcl.Pos{Line: 14, Column: 37},
+					},
+				},
+				{
+					Rule:    NewTerraformComputedCountForEachRule(),
+					Message: "for_each depends on a value that is unknown until apply: local.by_id -> aws_subnet.main[*].id",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 14},
+						End:      hcl.Pos{Line: 18, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformComputedCountForEachRule(),
+					Message: "count depends on a value that is unknown until apply: aws_security_group.main.id",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 22, Column: 11},
+						End:      hcl.Pos{Line: 22, Column: 51},
+					},
+				},
+			},
+		},
+		{
+			Name: "apply-time functions",
+			Content: `
+locals {
+  suffix = uuid()
+}
+
+resource "aws_s3_bucket" "main" {
+  for_each = { "logs-${local.suffix}" = "logs" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformComputedCountForEachRule(),
+					Message: "for_each depends on a value that is unknown until apply: local.suffix -> uuid()",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 14},
+						End:      hcl.Pos{Line: 7, Column: 49},
+					},
+				},
+			},
+		},
+		{
+			Name: "computed attributes",
+			Config: `
+rule "terraform_computed_count_for_each" {
+  enabled             = true
+  computed_attributes = ["private_ip"]
+}`,
+			Content: `
+resource "aws_instance" "main" {}
+
+resource "aws_route53_record" "main" {
+  for_each = toset([aws_instance.main.private_ip, aws_instance.main.id])
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformComputedCountForEachRule(),
+					Message: "for_each depends on a value that is unknown until apply: aws_instance.main.private_ip",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 14},
+						End:      hcl.Pos{Line: 5, Column: 73},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformComputedCountForEachRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
@@ -0,0 +1,282 @@
+package rules
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	tfsdk "github.com/terraform-linters/tflint-plugin-sdk/terraform"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// deprecatedFunction is a deprecated usage of a built-in function
+type deprecatedFunction struct {
+	// since is the Terraform version that introduced the replacement.
+	// Usages are reported only if every version allowed by required_version supports the replacement.
+	since *version.Version
+	// match returns whether the call is deprecated. nil matches all calls.
+	match   func(call *hclsyntax.FunctionCallExpr) bool
+	message string
+	// fix returns a fix for the call, or nil if it cannot be fixed automatically.
+	fix func(call *hclsyntax.FunctionCallExpr) func(f tflint.Fixer) error
+}
+
+// terraformV012 is the version that introduced tolist(), tomap(), templatefile(), and the index syntax for arbitrary expressions
+var terraformV012 = version.Must(version.NewVersion("0.12.0"))
+
+// deprecatedFunctions are deprecated usages of built-in functions, keyed by function name
+var deprecatedFunctions = map[string]deprecatedFunction{
+	"list": {
+		since:   terraformV012,
+		message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+		fix:     fixListFunction,
+	},
+	"map": {
+		since:   terraformV012,
+		message: "map() is deprecated and removed in Terraform v0.15. Use tomap({...}) instead",
+		fix:     fixMapFunction,
+	},
+	"element": {
+		since:   terraformV012,
+		match:   func(call *hclsyntax.FunctionCallExpr) bool { return negativeElementIndex(call) != nil },
+		message: "element() does not support negative indexes. Use the index syntax with length() instead",
+		fix:     fixElementFunction,
+	},
+}
+
+// deprecatedResource is a resource or data source type that should be replaced
+type deprecatedResource struct {
+	blockType    string
+	resourceType string
+	// since is the Terraform version that introduced the replacement.
+	since   *version.Version
+	message string
+}
+
+// deprecatedResources are resource and data source types that should be replaced with built-in features
+var deprecatedResources = []deprecatedResource{
+	{
+		blockType:    "data",
+		resourceType: "template_file",
+		since:        terraformV012,
+		message:      `data "template_file" is deprecated. Use the templatefile() function instead`,
+	},
+	{
+		blockType:    "resource",
+		resourceType: "null_resource",
+		since:        version.Must(version.NewVersion("1.4.0")),
+		message:      `"null_resource" can be replaced with the built-in "terraform_data" resource`,
+	},
+}
+
+// TerraformDeprecatedFunctionsRule checks whether deprecated functions and resources are used
+type TerraformDeprecatedFunctionsRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformDeprecatedFunctionsRule returns a new rule
+func NewTerraformDeprecatedFunctionsRule() *TerraformDeprecatedFunctionsRule {
+	return &TerraformDeprecatedFunctionsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformDeprecatedFunctionsRule) Name() string {
+	return "terraform_deprecated_functions"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformDeprecatedFunctionsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformDeprecatedFunctionsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformDeprecatedFunctionsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check walks all function calls and resources and emits issues for deprecated usages
+// whose replacements are available in the required Terraform version
+func (r *TerraformDeprecatedFunctionsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	constraints, err := runner.GetRequiredVersion()
+	if err != nil {
+		return err
+	}
+	minVersion := terraform.MinimumVersion(constraints)
+	available := func(since *version.Version) bool {
+		// Without constraints, the latest version is assumed.
+		return since == nil || minVersion == nil || minVersion.GreaterThanOrEqual(since)
+	}
+
+	diags := runner.WalkFunctionCalls(func(call *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+		deprecated, exists := deprecatedFunctions[call.Name]
+		if !exists || !available(deprecated.since) {
+			return nil
+		}
+		if deprecated.match != nil && !deprecated.match(call) {
+			return nil
+		}
+
+		fix := func(f tflint.Fixer) error {
+			if tfsdk.IsJSONFilename(call.Range().Filename) || deprecated.fix == nil {
+				return tflint.ErrFixNotSupported
+			}
+			if fixFunc := deprecated.fix(call); fixFunc != nil {
+				return fixFunc(f)
+			}
+			return tflint.ErrFixNotSupported
+		}
+		if err := runner.EmitIssueWithFix(r, deprecated.message, call.Range(), fix); err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssueWithFix()",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body:       &hclext.BodySchema{},
+			},
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+				Body:       &hclext.BodySchema{},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, block := range body.Blocks {
+		for _, deprecated := range deprecatedResources {
+			if block.Type != deprecated.blockType || block.Labels[0] != deprecated.resourceType || !available(deprecated.since) {
+				continue
+			}
+			if err := runner.EmitIssue(r, deprecated.message, block.DefRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// fixListFunction replaces list(a, b) with tolist([a, b]).
+// Only the text around the arguments is rewritten, so that nested calls can be fixed at the same time.
+func fixListFunction(call *hclsyntax.FunctionCallExpr) func(f tflint.Fixer) error {
+	if call.ExpandFinal {
+		return nil
+	}
+	return func(f tflint.Fixer) error {
+		texts := []any{"tolist(["}
+		for i, arg := range call.Args {
+			if i > 0 {
+				texts = append(texts, ", ")
+			}
+			texts = append(texts, f.TextAt(arg.Range()))
+		}
+		texts = append(texts, "])")
+		return f.ReplaceText(call.Range(), texts...)
+	}
+}
+
+// fixMapFunction replaces map(k1, v1, k2, v2) with tomap({ k1 = v1, k2 = v2 }).
+// Only the text around the arguments is rewritten, so that nested calls can be fixed at the same time.
+func fixMapFunction(call *hclsyntax.FunctionCallExpr) func(f tflint.Fixer) error {
+	if call.ExpandFinal || len(call.Args)%2 != 0 {
+		return nil
+	}
+	return func(f tflint.Fixer) error {
+		if len(call.Args) == 0 {
+			return f.ReplaceText(call.Range(), "tomap({})")
+		}
+
+		texts := []any{"tomap({ "}
+		for i := 0; i < len(call.Args); i += 2 {
+			if i > 0 {
+				texts = append(texts, ", ")
+			}
+			if _, literal := call.Args[i].(*hclsyntax.TemplateExpr); literal {
+				texts = append(texts, f.TextAt(call.Args[i].Range()), " = ")
+			} else {
+				// Keys other than strings must be parenthesized to be evaluated as expressions.
+				texts = append(texts, "(", f.TextAt(call.Args[i].Range()), ") = ")
+			}
+			texts = append(texts, f.TextAt(call.Args[i+1].Range()))
+		}
+		texts = append(texts, " })")
+		return f.ReplaceText(call.Range(), texts...)
+	}
+}
+
+// fixElementFunction replaces element(list, -n) with list[length(list) - n].
+// Lists other than simple references are parenthesized so that the index applies to the whole expression.
+// The list is referred to twice, and only the first one is rewritten together with nested calls.
+func fixElementFunction(call *hclsyntax.FunctionCallExpr) func(f tflint.Fixer) error {
+	index := negativeElementIndex(call)
+	if index == nil {
+		return nil
+	}
+	return func(f tflint.Fixer) error {
+		list := f.TextAt(call.Args[0].Range())
+		length := fmt.Sprintf("[length(%s) - %s]", list.Bytes, new(big.Int).Neg(index))
+		switch call.Args[0].(type) {
+		case *hclsyntax.ScopeTraversalExpr, *hclsyntax.ParenthesesExpr:
+			return f.ReplaceText(call.Range(), list, length)
+		default:
+			return f.ReplaceText(call.Range(), "(", list, ")", length)
+		}
+	}
+}
+
+// negativeElementIndex returns the index passed to element() if it is a negative integer literal
+func negativeElementIndex(call *hclsyntax.FunctionCallExpr) *big.Int {
+	if len(call.Args) != 2 || call.ExpandFinal {
+		return nil
+	}
+	if len(call.Args[1].Variables()) > 0 {
+		return nil
+	}
+	val, diags := call.Args[1].Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.Number {
+		return nil
+	}
+	index, accuracy := val.AsBigFloat().Int(nil)
+	if accuracy != big.Exact || index.Sign() >= 0 {
+		return nil
+	}
+	return index
+}
@@ -0,0 +1,275 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformDeprecatedFunctionsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "list and map",
+			Content: `
+locals {
+  a = list("a", "b")
+  b = map("a", 1, var.key, 2)
+  c = list()
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 7},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "map() is deprecated and removed in Terraform v0.15. Use tomap({...}) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 7},
+						End:      hcl.Pos{Line: 4, Column: 30},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 7},
+						End:      hcl.Pos{Line: 5, Column: 13},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  a = tolist(["a", "b"])
+  b = tomap({ "a" = 1, (var.key) = 2 })
+  c = tolist([])
+}`,
+		},
+		{
+			Name: "nested list and map",
+			Content: `
+locals {
+  a = list(list("a"), map("b", list()))
+  b = element(list("a"), -1)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 7},
+						End:      hcl.Pos{Line: 3, Column: 40},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "map() is deprecated and removed in Terraform v0.15. Use tomap({...}) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 23},
+						End:      hcl.Pos{Line: 3, Column: 39},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 32},
+						End:      hcl.Pos{Line: 3, Column: 38},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "element() does not support negative indexes. Use the index syntax with length() instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 7},
+						End:      hcl.Pos{Line: 4, Column: 29},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 15},
+						End:      hcl.Pos{Line: 4, Column: 24},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  a = tolist([tolist(["a"]), tomap({ "b" = tolist([]) })])
+  b = (tolist(["a"]))[length(list("a")) - 1]
+}`,
+		},
+		{
+			Name: "element with negative index",
+			Content: `
+locals {
+  a = element(var.list, -1)
+  b = element(var.list, 1)
+  c = element(var.list, var.index)
+  d = element(var.enabled ? var.list : [], -2)
+  e = element(concat(var.a, var.b), -1)
+  f = element((var.list), -1)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "element() does not support negative indexes. Use the index syntax with length() instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 7},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "element() does not support negative indexes. Use the index syntax with length() instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 7},
+						End:      hcl.Pos{Line: 6, Column: 47},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "element() does not support negative indexes. Use the index syntax with length() instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 7},
+						End:      hcl.Pos{Line: 7, Column: 40},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "element() does not support negative indexes. Use the index syntax with length() instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 7},
+						End:      hcl.Pos{Line: 8, Column: 30},
+					},
+				},
+			},
+			Fixed: `
+locals {
+  a = var.list[length(var.list) - 1]
+  b = element(var.list, 1)
+  c = element(var.list, var.index)
+  d = (var.enabled ? var.list : [])[length(var.enabled ? var.list : []) - 2]
+  e = (concat(var.a, var.b))[length(concat(var.a, var.b)) - 1]
+  f = (var.list)[length((var.list)) - 1]
+}`,
+		},
+		{
+			Name: "resources",
+			Content: `
+data "template_file" "user_data" {
+  template = file("user_data.tftpl")
+}
+
+resource "null_resource" "trigger" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: `data "template_file" is deprecated. Use the templatefile() function instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 33},
+					},
+				},
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: `"null_resource" can be replaced with the built-in "terraform_data" resource`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "replacement not available in required_version",
+			Content: `
+terraform {
+  required_version = ">= 1.3"
+}
+
+resource "null_resource" "trigger" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "locals": {
+    "a": "${list(\"a\")}"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformDeprecatedFunctionsRule(),
+					Message: "list() is deprecated and removed in Terraform v0.15. Use tolist([...]) instead",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 3, Column: 15},
+						End:      hcl.Pos{Line: 3, Column: 24},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformDeprecatedFunctionsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := testRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Runner.(*helper.Runner).Changes())
+		})
+	}
+}
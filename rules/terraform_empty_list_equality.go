@@ -1,13 +1,18 @@
 package rules
 
 import (
+	"fmt"
+
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
 )
 
-// TerraformEmptyListEqualityRule checks whether is there a comparison with an empty list
+// TerraformEmptyListEqualityRule checks whether is there a comparison with an empty list, or other comparisons that always have the same result
 type TerraformEmptyListEqualityRule struct {
 	tflint.DefaultRule
 }
@@ -48,45 +53,183 @@ func (r *TerraformEmptyListEqualityRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
-	if diags := r.checkEmptyList(runner); diags.HasErrors() {
+	variables, err := r.getVariables(runner)
+	if err != nil {
+		return err
+	}
+
+	if diags := r.checkEmptyList(runner, variables); diags.HasErrors() {
 		return diags
 	}
 
 	return nil
 }
 
-// checkEmptyList visits all blocks that can contain expressions and checks for comparisons with static empty list
-func (r *TerraformEmptyListEqualityRule) checkEmptyList(runner tflint.Runner) hcl.Diagnostics {
+// comparedVariable is the type information of a variable that is used to find fragile comparisons
+type comparedVariable struct {
+	// typeName is the name of the type constructor or primitive type, such as "list" or "number"
+	typeName string
+	nullable bool
+}
+
+// getVariables returns the variables with statically known types
+func (r *TerraformEmptyListEqualityRule) getVariables(runner tflint.Runner) (map[string]comparedVariable, error) {
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}, {Name: "nullable"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return nil, err
+	}
+
+	variables := map[string]comparedVariable{}
+	for _, variable := range body.Blocks {
+		typeAttr, exists := variable.Body.Attributes["type"]
+		if !exists {
+			continue
+		}
+		typeName := hcl.ExprAsKeyword(typeAttr.Expr)
+		if call, diags := hcl.ExprCall(typeAttr.Expr); !diags.HasErrors() {
+			typeName = call.Name
+		}
+
+		nullable := true
+		if attr, exists := variable.Body.Attributes["nullable"]; exists {
+			if val, known := staticBool(attr.Expr); known && !val {
+				nullable = false
+			}
+		}
+
+		variables[variable.Labels[0]] = comparedVariable{typeName: typeName, nullable: nullable}
+	}
+	return variables, nil
+}
+
+// checkEmptyList visits all blocks that can contain expressions and checks for comparisons with static empty list,
+// and other comparisons that always have the same result
+func (r *TerraformEmptyListEqualityRule) checkEmptyList(runner tflint.Runner, variables map[string]comparedVariable) hcl.Diagnostics {
 	return runner.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
-		if binaryOpExpr, ok := expr.(*hclsyntax.BinaryOpExpr); ok && (binaryOpExpr.Op == hclsyntax.OpEqual || binaryOpExpr.Op == hclsyntax.OpNotEqual) {
-			if tupleConsExpr, ok := binaryOpExpr.LHS.(*hclsyntax.TupleConsExpr); ok && len(tupleConsExpr.Exprs) == 0 {
-				if err := r.emitIssue(binaryOpExpr, binaryOpExpr.RHS, runner); err != nil {
-					return hcl.Diagnostics{
-						{
-							Severity: hcl.DiagError,
-							Summary:  "failed to call EmitIssueWithFix()",
-							Detail:   err.Error(),
-						},
-					}
-				}
-			} else if tupleConsExpr, ok := binaryOpExpr.RHS.(*hclsyntax.TupleConsExpr); ok && len(tupleConsExpr.Exprs) == 0 {
-				if err := r.emitIssue(binaryOpExpr, binaryOpExpr.LHS, runner); err != nil {
-					return hcl.Diagnostics{
-						{
-							Severity: hcl.DiagError,
-							Summary:  "failed to call EmitIssueWithFix()",
-							Detail:   err.Error(),
-						},
-					}
-				}
+		binaryOpExpr, ok := expr.(*hclsyntax.BinaryOpExpr)
+		if !ok || (binaryOpExpr.Op != hclsyntax.OpEqual && binaryOpExpr.Op != hclsyntax.OpNotEqual) {
+			return nil
+		}
+
+		if err := r.checkComparison(runner, binaryOpExpr, variables); err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssueWithFix()",
+					Detail:   err.Error(),
+				},
 			}
 		}
 		return nil
 	}))
 }
 
-// emitIssue emits issue for comparison with static empty list
-func (r *TerraformEmptyListEqualityRule) emitIssue(binaryOpExpr *hclsyntax.BinaryOpExpr, hs hcl.Expression, runner tflint.Runner) error {
+// checkComparison checks a comparison. Only the first problem found is reported,
+// since the fixes of the same comparison conflict with each other.
+func (r *TerraformEmptyListEqualityRule) checkComparison(runner tflint.Runner, binaryOpExpr *hclsyntax.BinaryOpExpr, variables map[string]comparedVariable) error {
+	sides := [][2]hclsyntax.Expression{
+		{binaryOpExpr.LHS, binaryOpExpr.RHS},
+		{binaryOpExpr.RHS, binaryOpExpr.LHS},
+	}
+
+	for _, side := range sides {
+		if tupleConsExpr, ok := side[0].(*hclsyntax.TupleConsExpr); ok && len(tupleConsExpr.Exprs) == 0 {
+			return r.emitIssue(binaryOpExpr, side[1], "Comparing a collection with an empty list is invalid. To detect an empty collection, check its length.", runner)
+		}
+	}
+	for _, side := range sides {
+		if objectConsExpr, ok := side[0].(*hclsyntax.ObjectConsExpr); ok && len(objectConsExpr.Items) == 0 {
+			return r.emitIssue(binaryOpExpr, side[1], "Comparing a collection with an empty map is invalid. To detect an empty collection, check its length.", runner)
+		}
+	}
+	for _, side := range sides {
+		if !isNullLiteral(side[0]) {
+			continue
+		}
+		name, ok := variableName(side[1])
+		if !ok {
+			continue
+		}
+		if variable, exists := variables[name]; exists && !variable.nullable && isCollectionType(variable.typeName) {
+			// This is not fixed, because a length check has a different meaning than the comparison,
+			// which may be intended for a variable that used to be nullable.
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("var.%s is a non-nullable collection, so comparing it with null is always %s. To detect an empty collection, check its length.", name, comparisonResult(binaryOpExpr)),
+				binaryOpExpr.Range(),
+			)
+		}
+	}
+	for _, side := range sides {
+		callExpr, ok := unwrapParens(side[0]).(*hclsyntax.FunctionCallExpr)
+		if !ok || (callExpr.Name != "tolist" && callExpr.Name != "toset") {
+			continue
+		}
+		tupleConsExpr, ok := side[1].(*hclsyntax.TupleConsExpr)
+		if !ok {
+			continue
+		}
+		return runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("Comparing the result of %s() with a tuple is always %s because their types differ. Convert the tuple with %s() too.", callExpr.Name, comparisonResult(binaryOpExpr), callExpr.Name),
+			binaryOpExpr.Range(),
+			func(f tflint.Fixer) error {
+				return f.ReplaceText(tupleConsExpr.Range(), callExpr.Name+"(", f.TextAt(tupleConsExpr.Range()), ")")
+			},
+		)
+	}
+
+	return r.checkStringNumberComparison(runner, binaryOpExpr, variables)
+}
+
+// checkStringNumberComparison checks for comparisons between a number and a string.
+// Values of different types are never equal, even if the string can be converted to the number.
+func (r *TerraformEmptyListEqualityRule) checkStringNumberComparison(runner tflint.Runner, binaryOpExpr *hclsyntax.BinaryOpExpr, variables map[string]comparedVariable) error {
+	var numberExpr, stringExpr hclsyntax.Expression
+	switch {
+	case staticType(binaryOpExpr.LHS, variables) == cty.Number && staticType(binaryOpExpr.RHS, variables) == cty.String:
+		numberExpr, stringExpr = binaryOpExpr.LHS, binaryOpExpr.RHS
+	case staticType(binaryOpExpr.LHS, variables) == cty.String && staticType(binaryOpExpr.RHS, variables) == cty.Number:
+		numberExpr, stringExpr = binaryOpExpr.RHS, binaryOpExpr.LHS
+	default:
+		return nil
+	}
+
+	return runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("Comparing a number with a string is always %s because values of different types are never equal. Convert one side so that both have the same type.", comparisonResult(binaryOpExpr)),
+		binaryOpExpr.Range(),
+		func(f tflint.Fixer) error {
+			// Prefer rewriting literals to adding conversions.
+			if literal, ok := numberExpr.(*hclsyntax.LiteralValueExpr); ok {
+				return f.ReplaceText(literal.Range(), fmt.Sprintf("%q", literal.Val.AsBigFloat().Text('f', -1)))
+			}
+			if template, ok := stringExpr.(*hclsyntax.TemplateExpr); ok && template.IsStringLiteral() {
+				val, diags := template.Value(nil)
+				if !diags.HasErrors() {
+					if number, err := convert.Convert(val, cty.Number); err == nil {
+						return f.ReplaceText(template.Range(), number.AsBigFloat().Text('f', -1))
+					}
+				}
+			}
+			return f.ReplaceText(stringExpr.Range(), "tonumber(", f.TextAt(stringExpr.Range()), ")")
+		},
+	)
+}
+
+// emitIssue emits issue for comparison with static empty collection.
+// The fix replaces the comparison with a length check of the other side.
+func (r *TerraformEmptyListEqualityRule) emitIssue(binaryOpExpr *hclsyntax.BinaryOpExpr, hs hcl.Expression, message string, runner tflint.Runner) error {
 	var opStr string
 	if binaryOpExpr.Op == hclsyntax.OpEqual {
 		opStr = "=="
@@ -96,10 +239,90 @@ func (r *TerraformEmptyListEqualityRule) emitIssue(binaryOpExpr *hclsyntax.Binar
 
 	return runner.EmitIssueWithFix(
 		r,
-		"Comparing a collection with an empty list is invalid. To detect an empty collection, check its length.",
+		message,
 		binaryOpExpr.Range(),
 		func(f tflint.Fixer) error {
 			return f.ReplaceText(binaryOpExpr.Range(), "length(", f.TextAt(hs.Range()), ") ", opStr, " 0")
 		},
 	)
 }
+
+// comparisonResult returns the result of a comparison between values that are never equal
+func comparisonResult(binaryOpExpr *hclsyntax.BinaryOpExpr) string {
+	if binaryOpExpr.Op == hclsyntax.OpEqual {
+		return "false"
+	}
+	return "true"
+}
+
+func unwrapParens(expr hclsyntax.Expression) hclsyntax.Expression {
+	for {
+		parens, ok := expr.(*hclsyntax.ParenthesesExpr)
+		if !ok {
+			return expr
+		}
+		expr = parens.Expression
+	}
+}
+
+func isNullLiteral(expr hclsyntax.Expression) bool {
+	literal, ok := unwrapParens(expr).(*hclsyntax.LiteralValueExpr)
+	return ok && literal.Val.IsNull()
+}
+
+func isCollectionType(typeName string) bool {
+	switch typeName {
+	case "list", "set", "map", "tuple", "object":
+		return true
+	default:
+		return false
+	}
+}
+
+// variableName returns the name of the variable if the expression is a reference like var.foo
+func variableName(expr hclsyntax.Expression) (string, bool) {
+	traversal, ok := unwrapParens(expr).(*hclsyntax.ScopeTraversalExpr)
+	if !ok || len(traversal.Traversal) != 2 || traversal.Traversal.RootName() != "var" {
+		return "", false
+	}
+	attr, ok := traversal.Traversal[1].(hcl.TraverseAttr)
+	if !ok {
+		return "", false
+	}
+	return attr.Name, true
+}
+
+// staticType returns the type of the expression if it is known without evaluation, or cty.DynamicPseudoType otherwise
+func staticType(expr hclsyntax.Expression, variables map[string]comparedVariable) cty.Type {
+	switch expr := unwrapParens(expr).(type) {
+	case *hclsyntax.LiteralValueExpr:
+		if expr.Val.IsNull() {
+			return cty.DynamicPseudoType
+		}
+		return expr.Val.Type()
+	case *hclsyntax.TemplateExpr:
+		return cty.String
+	case *hclsyntax.ScopeTraversalExpr:
+		if expr.Traversal.RootName() == "count" && len(expr.Traversal) == 2 {
+			if attr, ok := expr.Traversal[1].(hcl.TraverseAttr); ok && attr.Name == "index" {
+				return cty.Number
+			}
+		}
+		if name, ok := variableName(expr); ok {
+			switch variables[name].typeName {
+			case "number":
+				return cty.Number
+			case "string":
+				return cty.String
+			}
+		}
+	case *hclsyntax.FunctionCallExpr:
+		switch expr.Name {
+		case "length", "tonumber", "abs", "ceil", "floor", "max", "min", "parseint":
+			return cty.Number
+		case "tostring", "format", "join", "lower", "upper", "trimspace", "replace", "substr":
+			return cty.String
+		}
+	}
+	return cty.DynamicPseudoType
+}
@@ -170,6 +170,164 @@ resource "aws_db_instance" "mysql" {
   instance_class = "m4.2xlarge"
 }`,
 		},
+		{
+			Name: "comparing with {} is not recommended",
+			Content: `
+variable "tags" {
+  type = map(string)
+}
+resource "aws_db_instance" "mysql" {
+  count          = var.tags != {} ? 1 : 0
+  instance_class = "m4.2xlarge"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "Comparing a collection with an empty map is invalid. To detect an empty collection, check its length.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 6, Column: 20},
+						End:      hcl.Pos{Line: 6, Column: 34},
+					},
+				},
+			},
+			Fixed: `
+variable "tags" {
+  type = map(string)
+}
+resource "aws_db_instance" "mysql" {
+  count          = length(var.tags) != 0 ? 1 : 0
+  instance_class = "m4.2xlarge"
+}`,
+		},
+		{
+			Name: "comparing a non-nullable collection with null",
+			Content: `
+variable "subnets" {
+  type     = list(string)
+  nullable = false
+}
+variable "nullable_subnets" {
+  type = list(string)
+}
+variable "name" {
+  type     = string
+  nullable = false
+}
+resource "aws_db_instance" "mysql" {
+  count          = var.subnets == null ? 0 : 1
+  instance_class = var.nullable_subnets == null || var.name == null ? "m4.large" : "m4.2xlarge"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "var.subnets is a non-nullable collection, so comparing it with null is always false. To detect an empty collection, check its length.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 14, Column: 20},
+						End:      hcl.Pos{Line: 14, Column: 39},
+					},
+				},
+			},
+		},
+		{
+			Name: "comparing tolist() with a tuple",
+			Content: `
+variable "zones" {
+  type = list(string)
+}
+resource "aws_db_instance" "mysql" {
+  count          = toset(var.zones) != ["a", "b"] ? 1 : 0
+  instance_class = "m4.2xlarge"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "Comparing the result of toset() with a tuple is always true because their types differ. Convert the tuple with toset() too.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 6, Column: 20},
+						End:      hcl.Pos{Line: 6, Column: 50},
+					},
+				},
+			},
+			Fixed: `
+variable "zones" {
+  type = list(string)
+}
+resource "aws_db_instance" "mysql" {
+  count          = toset(var.zones) != toset(["a", "b"]) ? 1 : 0
+  instance_class = "m4.2xlarge"
+}`,
+		},
+		{
+			Name: "comparing a number with a string",
+			Content: `
+variable "instance_count" {
+  type = number
+}
+variable "size" {
+  type = string
+}
+resource "aws_db_instance" "mysql" {
+  count          = var.instance_count == "1" ? 1 : 0
+  instance_class = var.size == 2 ? "m4.large" : "m4.2xlarge"
+  name           = count.index != var.size ? "a" : "b"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "Comparing a number with a string is always false because values of different types are never equal. Convert one side so that both have the same type.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 9, Column: 20},
+						End:      hcl.Pos{Line: 9, Column: 45},
+					},
+				},
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "Comparing a number with a string is always false because values of different types are never equal. Convert one side so that both have the same type.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 10, Column: 20},
+						End:      hcl.Pos{Line: 10, Column: 33},
+					},
+				},
+				{
+					Rule:    NewTerraformEmptyListEqualityRule(),
+					Message: "Comparing a number with a string is always true because values of different types are never equal. Convert one side so that both have the same type.",
+					Range: hcl.Range{
+						Filename: "resource.tf",
+						Start:    hcl.Pos{Line: 11, Column: 20},
+						End:      hcl.Pos{Line: 11, Column: 43},
+					},
+				},
+			},
+			Fixed: `
+variable "instance_count" {
+  type = number
+}
+variable "size" {
+  type = string
+}
+resource "aws_db_instance" "mysql" {
+  count          = var.instance_count == 1 ? 1 : 0
+  instance_class = var.size == "2" ? "m4.large" : "m4.2xlarge"
+  name           = count.index != tonumber(var.size) ? "a" : "b"
+}`,
+		},
+		{
+			Name: "comparing values of the same type",
+			Content: `
+variable "instance_count" {
+  type = number
+}
+resource "aws_db_instance" "mysql" {
+  count          = var.instance_count == 1 && tolist(["a"]) == tolist(["a"]) ? 1 : 0
+  instance_class = "m4.2xlarge"
+}`,
+			Expected: helper.Issues{},
+		},
 	}
 
 	rule := NewTerraformEmptyListEqualityRule()
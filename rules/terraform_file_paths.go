@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfsdk "github.com/terraform-linters/tflint-plugin-sdk/terraform"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// filePathFunctions are functions that read a file or a directory given as the first argument
+var filePathFunctions = map[string]bool{
+	"file":         true,
+	"filebase64":   true,
+	"filemd5":      true,
+	"fileset":      true,
+	"templatefile": true,
+}
+
+// TerraformFilePathsRule checks whether paths passed to file functions exist and are relative to path.module
+type TerraformFilePathsRule struct {
+	tflint.DefaultRule
+}
+
+type terraformFilePathsRuleConfig struct {
+	// CheckExistence specifies whether the rule should report files that do not exist
+	CheckExistence *bool `hclext:"check_existence,optional"`
+	// GeneratedPaths are glob patterns of paths relative to the module directory that are created at apply time
+	GeneratedPaths []string `hclext:"generated_paths,optional"`
+}
+
+// NewTerraformFilePathsRule returns a new rule
+func NewTerraformFilePathsRule() *TerraformFilePathsRule {
+	return &TerraformFilePathsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformFilePathsRule) Name() string {
+	return "terraform_file_paths"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformFilePathsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformFilePathsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformFilePathsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether static paths passed to file functions exist and are prefixed with path.module
+func (r *TerraformFilePathsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformFilePathsRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	for _, pattern := range config.GeneratedPaths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid generated_paths pattern %q: %w", pattern, err)
+		}
+	}
+
+	diags := runner.WalkFunctionCalls(func(call *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+		if !filePathFunctions[call.Name] || len(call.Args) == 0 {
+			return nil
+		}
+		if err := r.checkFilePath(runner, call, config); err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssue()",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	return nil
+}
+
+func (r *TerraformFilePathsRule) checkFilePath(runner tflint.Runner, call *hclsyntax.FunctionCallExpr, config terraformFilePathsRuleConfig) error {
+	arg := call.Args[0]
+	filename, usesPathModule, ok := staticFilePath(arg)
+	if !ok {
+		return nil
+	}
+
+	if !usesPathModule && !filepath.IsAbs(filename) {
+		relative := filepath.ToSlash(filename)
+		if err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf(`Relative path %q in %s() is resolved from the working directory. Use "${path.module}/%s" instead`, relative, call.Name, strings.TrimPrefix(relative, "./")),
+			arg.Range(),
+			func(f tflint.Fixer) error {
+				if tfsdk.IsJSONFilename(arg.Range().Filename) || strings.ContainsAny(relative, `"\$%`) {
+					return tflint.ErrFixNotSupported
+				}
+				return f.ReplaceText(arg.Range(), fmt.Sprintf(`"${path.module}/%s"`, strings.TrimPrefix(relative, "./")))
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	if config.CheckExistence != nil && !*config.CheckExistence {
+		return nil
+	}
+
+	// Check existence as the module author intended.
+	filename, _ = resolveFilePath(arg)
+	if isGeneratedPath(filename, filepath.Dir(arg.Range().Filename), config.GeneratedPaths) {
+		return nil
+	}
+
+	info, err := os.Stat(filename)
+	switch {
+	case os.IsNotExist(err) && call.Name == "fileset":
+		return runner.EmitIssue(r, fmt.Sprintf("Directory %q does not exist", filepath.ToSlash(filename)), arg.Range())
+	case os.IsNotExist(err):
+		return runner.EmitIssue(r, fmt.Sprintf("File %q does not exist", filepath.ToSlash(filename)), arg.Range())
+	case err != nil:
+		return nil
+	case call.Name == "fileset" && !info.IsDir():
+		return runner.EmitIssue(r, fmt.Sprintf("%q is not a directory", filepath.ToSlash(filename)), arg.Range())
+	case call.Name != "fileset" && info.IsDir():
+		return runner.EmitIssue(r, fmt.Sprintf("%q is a directory", filepath.ToSlash(filename)), arg.Range())
+	}
+
+	return nil
+}
+
+// isGeneratedPath returns whether the file, or any of its parent directories in the module directory,
+// matches one of the patterns
+func isGeneratedPath(filename string, dir string, patterns []string) bool {
+	rel, err := filepath.Rel(dir, filename)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	for _, pattern := range patterns {
+		for p := rel; p != "." && p != "/"; p = path.Dir(p) {
+			if matched, _ := path.Match(pattern, p); matched {
+				return true
+			}
+		}
+	}
+	return false
+}
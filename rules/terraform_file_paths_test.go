@@ -0,0 +1,206 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformFilePathsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected func(dir string) helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "existing files",
+			Content: `
+locals {
+  policy    = file("${path.module}/files/policy.json")
+  encoded   = filebase64("${path.module}/files/policy.json")
+  checksum  = filemd5("${path.module}/files/policy.json")
+  templates = fileset("${path.module}/files", "*.json")
+  user_data = templatefile("${path.module}/files/policy.json", {})
+  dynamic   = file(var.path)
+  root      = file("${path.root}/missing.txt")
+}`,
+			Expected: func(string) helper.Issues { return helper.Issues{} },
+		},
+		{
+			Name: "missing files",
+			Content: `
+locals {
+  policy    = file("${path.module}/files/missing.json")
+  templates = fileset("${path.module}/templates", "*.tftpl")
+  directory = file("${path.module}/files")
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: fmt.Sprintf("File %q does not exist", filepath.ToSlash(filepath.Join(dir, "files", "missing.json"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 3, Column: 20},
+							End:      hcl.Pos{Line: 3, Column: 55},
+						},
+					},
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: fmt.Sprintf("Directory %q does not exist", filepath.ToSlash(filepath.Join(dir, "templates"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 23},
+							End:      hcl.Pos{Line: 4, Column: 49},
+						},
+					},
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: fmt.Sprintf("%q is a directory", filepath.ToSlash(filepath.Join(dir, "files"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 5, Column: 20},
+							End:      hcl.Pos{Line: 5, Column: 42},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "relative paths",
+			Content: `
+locals {
+  policy  = file("files/policy.json")
+  missing = file("./files/missing.json")
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: `Relative path "files/policy.json" in file() is resolved from the working directory. Use "${path.module}/files/policy.json" instead`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 3, Column: 18},
+							End:      hcl.Pos{Line: 3, Column: 37},
+						},
+					},
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: `Relative path "./files/missing.json" in file() is resolved from the working directory. Use "${path.module}/files/missing.json" instead`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 18},
+							End:      hcl.Pos{Line: 4, Column: 40},
+						},
+					},
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: fmt.Sprintf("File %q does not exist", filepath.ToSlash(filepath.Join(dir, "files", "missing.json"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 18},
+							End:      hcl.Pos{Line: 4, Column: 40},
+						},
+					},
+				}
+			},
+			Fixed: `
+locals {
+  policy  = file("${path.module}/files/policy.json")
+  missing = file("${path.module}/files/missing.json")
+}`,
+		},
+		{
+			Name: "generated paths",
+			Config: `
+rule "terraform_file_paths" {
+  enabled         = true
+  generated_paths = ["build", "*.zip"]
+}`,
+			Content: `
+locals {
+  binary  = filebase64("${path.module}/build/lambda/bootstrap")
+  archive = filemd5("${path.module}/lambda.zip")
+  missing = file("${path.module}/files/missing.json")
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: fmt.Sprintf("File %q does not exist", filepath.ToSlash(filepath.Join(dir, "files", "missing.json"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 5, Column: 18},
+							End:      hcl.Pos{Line: 5, Column: 53},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "existence check disabled",
+			Config: `
+rule "terraform_file_paths" {
+  enabled         = true
+  check_existence = false
+}`,
+			Content: `
+locals {
+  missing  = file("${path.module}/files/missing.json")
+  relative = file("files/missing.json")
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformFilePathsRule(),
+						Message: `Relative path "files/missing.json" in file() is resolved from the working directory. Use "${path.module}/files/missing.json" instead`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 19},
+							End:      hcl.Pos{Line: 4, Column: 39},
+						},
+					},
+				}
+			},
+			Fixed: `
+locals {
+  missing  = file("${path.module}/files/missing.json")
+  relative = file("${path.module}/files/missing.json")
+}`,
+		},
+	}
+
+	rule := NewTerraformFilePathsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, "files"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "files", "policy.json"), []byte("{}"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			filename := filepath.Join(dir, "main.tf")
+			runner := testRunner(t, map[string]string{filename: tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected(dir), runner.Runner.(*helper.Runner).Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Runner.(*helper.Runner).Changes())
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package rules
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// TerraformFmtRule checks whether files are formatted in the canonical style
+type TerraformFmtRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformFmtRule returns a new rule
+func NewTerraformFmtRule() *TerraformFmtRule {
+	return &TerraformFmtRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformFmtRule) Name() string {
+	return "terraform_fmt"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformFmtRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformFmtRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformFmtRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether files are formatted in the same way as `terraform fmt`
+func (r *TerraformFmtRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for name, file := range files {
+		if err := r.checkFormat(runner, name, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformFmtRule) checkFormat(runner tflint.Runner, filename string, file *hcl.File) error {
+	if strings.HasSuffix(filename, ".json") {
+		return nil
+	}
+
+	formatted := hclwrite.Format(file.Bytes)
+	if bytes.Equal(file.Bytes, formatted) {
+		return nil
+	}
+
+	fix := func(f tflint.Fixer) error {
+		return f.ReplaceText(fileRange(filename, file.Bytes), string(formatted))
+	}
+
+	for _, rng := range changedLineRanges(filename, file.Bytes, formatted) {
+		if err := runner.EmitIssueWithFix(r, "Code is not formatted in the canonical style", rng, fix); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// changedLineRanges returns ranges of consecutive lines that differ between src and formatted.
+// Formatting only rewrites spaces within lines, so lines are compared one by one.
+// If the number of lines has changed anyway, the whole file is returned.
+func changedLineRanges(filename string, src []byte, formatted []byte) []hcl.Range {
+	srcLines := bytes.SplitAfter(src, []byte("\n"))
+	formattedLines := bytes.SplitAfter(formatted, []byte("\n"))
+	if len(srcLines) != len(formattedLines) {
+		return []hcl.Range{fileRange(filename, src)}
+	}
+
+	ranges := []hcl.Range{}
+	offset := 0
+	var current *hcl.Range
+	for i, line := range srcLines {
+		if bytes.Equal(line, formattedLines[i]) {
+			if current != nil {
+				ranges = append(ranges, *current)
+				current = nil
+			}
+			offset += len(line)
+			continue
+		}
+
+		content := bytes.TrimSuffix(line, []byte("\n"))
+		end := hcl.Pos{Line: i + 1, Column: len(content) + 1, Byte: offset + len(content)}
+		if current == nil {
+			current = &hcl.Range{
+				Filename: filename,
+				Start:    hcl.Pos{Line: i + 1, Column: 1, Byte: offset},
+			}
+		}
+		current.End = end
+		offset += len(line)
+	}
+	if current != nil {
+		ranges = append(ranges, *current)
+	}
+
+	return ranges
+}
+
+// fileRange returns the range covering the entire file
+func fileRange(filename string, src []byte) hcl.Range {
+	return hcl.Range{
+		Filename: filename,
+		Start:    hcl.InitialPos,
+		End:      posAt(src, len(src)),
+	}
+}
@@ -0,0 +1,134 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformFmtRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "formatted",
+			Content: `
+resource "aws_instance" "web" {
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "misaligned arguments",
+			Content: `
+resource "aws_instance" "web" {
+  ami = "ami-12345678"
+  instance_type = "t2.micro"
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFmtRule(),
+					Message: "Code is not formatted in the canonical style",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  ami           = "ami-12345678"
+  instance_type = "t2.micro"
+}
+`,
+		},
+		{
+			Name: "multiple hunks",
+			Content: `
+variable "foo" {
+    type = string
+}
+
+variable "bar" {
+  type = string
+}
+
+variable "baz" {
+type=string
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFmtRule(),
+					Message: "Code is not formatted in the canonical style",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+				{
+					Rule:    NewTerraformFmtRule(),
+					Message: "Code is not formatted in the canonical style",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 1},
+						End:      hcl.Pos{Line: 11, Column: 12},
+					},
+				},
+			},
+			Fixed: `
+variable "foo" {
+  type = string
+}
+
+variable "bar" {
+  type = string
+}
+
+variable "baz" {
+  type = string
+}
+`,
+		},
+		{
+			Name:     "JSON",
+			JSON:     true,
+			Content:  `{"variable": {"foo": {"type": "string"}}}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformFmtRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
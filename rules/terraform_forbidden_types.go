@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// TerraformForbiddenTypesRule checks whether resources, data sources, ephemeral resources, and provisioners of forbidden types are used
+type TerraformForbiddenTypesRule struct {
+	tflint.DefaultRule
+}
+
+type terraformForbiddenTypesRuleConfig struct {
+	Resources    []terraformForbiddenTypeConfig `hclext:"resource,block"`
+	Data         []terraformForbiddenTypeConfig `hclext:"data,block"`
+	Ephemeral    []terraformForbiddenTypeConfig `hclext:"ephemeral,block"`
+	Provisioners []terraformForbiddenTypeConfig `hclext:"provisioner,block"`
+}
+
+// terraformForbiddenTypeConfig forbids types matching the glob pattern
+type terraformForbiddenTypeConfig struct {
+	Type        string `hclext:"type,label"`
+	Message     string `hclext:"message"`
+	Replacement string `hclext:"replacement,optional"`
+}
+
+// NewTerraformForbiddenTypesRule returns a new rule
+func NewTerraformForbiddenTypesRule() *TerraformForbiddenTypesRule {
+	return &TerraformForbiddenTypesRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformForbiddenTypesRule) Name() string {
+	return "terraform_forbidden_types"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformForbiddenTypesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformForbiddenTypesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *TerraformForbiddenTypesRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether the types of resources, data sources, ephemeral resources, and provisioners
+// match the configured patterns
+func (r *TerraformForbiddenTypesRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformForbiddenTypesRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body: &hclext.BodySchema{
+					Blocks: []hclext.BlockSchema{
+						{Type: "provisioner", LabelNames: []string{"type"}},
+					},
+				},
+			},
+			{Type: "data", LabelNames: []string{"type", "name"}},
+			{Type: "ephemeral", LabelNames: []string{"type", "name"}},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, block := range body.Blocks {
+		var entries []terraformForbiddenTypeConfig
+		var kind string
+		switch block.Type {
+		case "resource":
+			entries, kind = config.Resources, "Resource type"
+		case "data":
+			entries, kind = config.Data, "Data source type"
+		case "ephemeral":
+			entries, kind = config.Ephemeral, "Ephemeral resource type"
+		}
+		if err := r.checkType(runner, entries, kind, block.Labels[0], block.DefRange); err != nil {
+			return err
+		}
+
+		for _, provisioner := range block.Body.Blocks {
+			if err := r.checkType(runner, config.Provisioners, "Provisioner", provisioner.Labels[0], provisioner.DefRange); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformForbiddenTypesRule) checkType(runner tflint.Runner, entries []terraformForbiddenTypeConfig, kind string, typeName string, rng hcl.Range) error {
+	for _, entry := range entries {
+		matched, err := matchTypePattern(entry.Type, typeName)
+		if err != nil {
+			return err
+		}
+		if !matched {
+			continue
+		}
+
+		message := fmt.Sprintf("%s %q is forbidden: %s", kind, typeName, strings.TrimSuffix(entry.Message, "."))
+		if entry.Replacement != "" {
+			message += fmt.Sprintf(". Use %q instead", entry.Replacement)
+		}
+		// Only the first matching entry is reported.
+		return runner.EmitIssue(r, message, rng)
+	}
+
+	return nil
+}
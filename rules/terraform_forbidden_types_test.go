@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformForbiddenTypesRule(t *testing.T) {
+	config := `
+rule "terraform_forbidden_types" {
+  enabled = true
+
+  resource "aws_iam_user" {
+    message     = "IAM users are managed in SSO."
+    replacement = "aws_identitystore_user"
+  }
+
+  resource "*_access_key" {
+    message = "Long-lived access keys are not allowed"
+  }
+
+  data "external" {
+    message = "External programs are not reproducible"
+  }
+
+  ephemeral "random_*" {
+    message     = "Random values are generated outside the provider"
+    replacement = "ephemeral.aws_secretsmanager_random_password"
+  }
+
+  provisioner "local-exec" {
+    message = "Provisioners run outside the plan"
+  }
+}`
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no config",
+			Content: `
+resource "aws_iam_user" "admin" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "allowed types",
+			Config: config,
+			Content: `
+resource "aws_iam_role" "admin" {
+  provisioner "remote-exec" {}
+}
+
+data "aws_ami" "web" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "forbidden types",
+			Config: config,
+			Content: `
+resource "aws_iam_user" "admin" {}
+
+resource "aws_iam_access_key" "admin" {
+  provisioner "local-exec" {
+    command = "echo done"
+  }
+}
+
+data "external" "script" {}
+
+ephemeral "random_password" "db" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformForbiddenTypesRule(),
+					Message: `Resource type "aws_iam_user" is forbidden: IAM users are managed in SSO. Use "aws_identitystore_user" instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 32},
+					},
+				},
+				{
+					Rule:    NewTerraformForbiddenTypesRule(),
+					Message: `Resource type "aws_iam_access_key" is forbidden: Long-lived access keys are not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 38},
+					},
+				},
+				{
+					Rule:    NewTerraformForbiddenTypesRule(),
+					Message: `Provisioner "local-exec" is forbidden: Provisioners run outside the plan`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 3},
+						End:      hcl.Pos{Line: 5, Column: 27},
+					},
+				},
+				{
+					Rule:    NewTerraformForbiddenTypesRule(),
+					Message: `Data source type "external" is forbidden: External programs are not reproducible`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 1},
+						End:      hcl.Pos{Line: 10, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformForbiddenTypesRule(),
+					Message: `Ephemeral resource type "random_password" is forbidden: Random values are generated outside the provider. Use "ephemeral.aws_secretsmanager_random_password" instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 1},
+						End:      hcl.Pos{Line: 12, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformForbiddenTypesRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
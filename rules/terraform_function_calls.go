@@ -0,0 +1,162 @@
+package rules
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/agext/levenshtein"
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// TerraformFunctionCallsRule checks whether function calls refer to built-in functions with valid arguments
+type TerraformFunctionCallsRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformFunctionCallsRule returns a new rule
+func NewTerraformFunctionCallsRule() *TerraformFunctionCallsRule {
+	return &TerraformFunctionCallsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformFunctionCallsRule) Name() string {
+	return "terraform_function_calls"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformFunctionCallsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformFunctionCallsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *TerraformFunctionCallsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether function calls refer to functions available in the required Terraform version
+// and pass a valid number of arguments
+func (r *TerraformFunctionCallsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	constraints, err := runner.GetRequiredVersion()
+	if err != nil {
+		return err
+	}
+	minVersion := terraform.MinimumVersion(constraints)
+
+	diags := runner.WalkFunctionCalls(func(call *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+		if err := r.checkFunctionCall(runner, call, minVersion); err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssue()",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	return nil
+}
+
+func (r *TerraformFunctionCallsRule) checkFunctionCall(runner tflint.Runner, call *hclsyntax.FunctionCallExpr, minVersion *version.Version) error {
+	// Built-in functions can also be called with the "core::" namespace.
+	name := strings.TrimPrefix(call.Name, "core::")
+	if strings.Contains(name, "::") {
+		// Provider-defined functions are declared by providers, not Terraform.
+		return nil
+	}
+
+	fn, exists := terraform.Functions[name]
+	if !exists {
+		message := fmt.Sprintf("Call to unknown function %q", call.Name)
+		if suggestion := functionNameSuggestion(name); suggestion != "" {
+			message += fmt.Sprintf(`. Did you mean "%s"?`, suggestion)
+		}
+		return runner.EmitIssue(r, message, call.NameRange)
+	}
+
+	if fn.Since != nil && minVersion != nil && minVersion.LessThan(fn.Since) {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Function %q is not available in Terraform %s, which is allowed by required_version. It was added in Terraform %s", call.Name, minVersion, fn.Since),
+			call.NameRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	if call.ExpandFinal {
+		// The number of arguments cannot be determined statically.
+		return nil
+	}
+	args := len(call.Args)
+	if args >= fn.MinArgs && (fn.MaxArgs == -1 || args <= fn.MaxArgs) {
+		return nil
+	}
+
+	var expected string
+	switch {
+	case fn.MinArgs == fn.MaxArgs:
+		expected = pluralizeArguments(fn.MinArgs)
+	case fn.MaxArgs == -1:
+		expected = "at least " + pluralizeArguments(fn.MinArgs)
+	default:
+		expected = fmt.Sprintf("%d to %s", fn.MinArgs, pluralizeArguments(fn.MaxArgs))
+	}
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("Function %q expects %s, but got %d", call.Name, expected, args),
+		call.Range(),
+	)
+}
+
+// functionNameSuggestion returns the most similar built-in function name, or an empty string if there is none
+func functionNameSuggestion(name string) string {
+	names := make([]string, 0, len(terraform.Functions))
+	for candidate := range terraform.Functions {
+		names = append(names, candidate)
+	}
+	sort.Strings(names)
+
+	suggestion := ""
+	bestDistance := 3
+	for _, candidate := range names {
+		if distance := levenshtein.Distance(name, candidate, nil); distance < bestDistance {
+			suggestion = candidate
+			bestDistance = distance
+		}
+	}
+	return suggestion
+}
+
+func pluralizeArguments(n int) string {
+	if n == 1 {
+		return "1 argument"
+	}
+	return fmt.Sprintf("%d arguments", n)
+}
@@ -0,0 +1,171 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformFunctionCallsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+	}{
+		{
+			Name: "valid calls",
+			Content: `
+locals {
+  a = length(var.list)
+  b = lookup(var.map, "key", null)
+  c = format("%s-%s", "a", "b")
+  d = max(var.numbers...)
+  e = provider::aws::arn_parse(var.arn)
+  f = core::upper("foo")
+  g = strcontains("foo", "o")
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unknown functions",
+			Content: `
+locals {
+  a = lenght(var.list)
+  b = frobnicate(var.list)
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Call to unknown function "lenght". Did you mean "length"?`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 7},
+						End:      hcl.Pos{Line: 3, Column: 13},
+					},
+				},
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Call to unknown function "frobnicate"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 7},
+						End:      hcl.Pos{Line: 4, Column: 17},
+					},
+				},
+			},
+		},
+		{
+			Name: "wrong argument counts",
+			Content: `
+locals {
+  a = replace("foo", "o")
+  b = lookup(var.map)
+  c = format()
+  d = length(upper("a", "b"))
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Function "replace" expects 3 arguments, but got 2`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 7},
+						End:      hcl.Pos{Line: 3, Column: 26},
+					},
+				},
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Function "lookup" expects 2 to 3 arguments, but got 1`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 7},
+						End:      hcl.Pos{Line: 4, Column: 22},
+					},
+				},
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Function "format" expects at least 1 argument, but got 0`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 7},
+						End:      hcl.Pos{Line: 5, Column: 15},
+					},
+				},
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Function "upper" expects 1 argument, but got 2`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 14},
+						End:      hcl.Pos{Line: 6, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name: "functions newer than required_version",
+			Content: `
+terraform {
+  required_version = ">= 1.3"
+}
+
+locals {
+  a = startswith(var.name, "foo")
+  b = strcontains(var.name, "foo")
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Function "strcontains" is not available in Terraform 1.3.0, which is allowed by required_version. It was added in Terraform 1.5.0`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 7},
+						End:      hcl.Pos{Line: 8, Column: 18},
+					},
+				},
+			},
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "locals": {
+    "a": "${lenght(var.list)}"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformFunctionCallsRule(),
+					Message: `Call to unknown function "lenght". Did you mean "length"?`,
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 3, Column: 15},
+						End:      hcl.Pos{Line: 3, Column: 21},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformFunctionCallsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := testRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
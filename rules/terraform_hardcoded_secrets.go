@@ -0,0 +1,284 @@
+package rules
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/json"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// secretNameRegexp matches argument names that are expected to hold credentials
+var secretNameRegexp = regexp.MustCompile(`(?i)(^|_)(password|secret|secret_key|token|access_key|private_key)$`)
+
+// placeholderRegexp matches values that are obviously not real credentials, such as "<token>" or "REPLACE_ME"
+var placeholderRegexp = regexp.MustCompile(`(?i)^(<.*>|\{\{.*\}\}|x+|\*+|\.+|-+|change[_-]?me|replace[_-]?me|todo|tbd|fixme|placeholder|dummy|example|none|null|n/a)$`)
+
+// digestNameRegexp matches argument names that hold hashes of content. Their values are random-looking but not secret.
+var digestNameRegexp = regexp.MustCompile(`(?i)(^|_)(hash|digest|checksum|sha\d*|md5|etag|fingerprint|thumbprint)(_|$)`)
+
+// secretFormats are well-known formats of credentials
+var secretFormats = []struct {
+	description string
+	regexp      *regexp.Regexp
+}{
+	{"an AWS access key ID", regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"a private key", regexp.MustCompile(`-----BEGIN ((RSA|EC|DSA|OPENSSH|ENCRYPTED) )?PRIVATE KEY-----`)},
+	{"a GitHub token", regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,}\b`)},
+	{"a Slack token", regexp.MustCompile(`\bxox[abprs]-[0-9A-Za-z-]{10,}\b`)},
+	{"a Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`)},
+	{"a Stripe secret key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{24,}\b`)},
+}
+
+// highEntropyCandidateRegexp matches strings that may be randomly generated tokens
+var highEntropyCandidateRegexp = regexp.MustCompile(`^[0-9A-Za-z+/=_-]{32,}$`)
+
+const highEntropyThreshold = 4.5
+
+// TerraformHardcodedSecretsRule checks whether credentials are hardcoded in configuration
+type TerraformHardcodedSecretsRule struct {
+	tflint.DefaultRule
+}
+
+type terraformHardcodedSecretsRuleConfig struct {
+	Allowlist []string `hclext:"allowlist,optional"`
+}
+
+// NewTerraformHardcodedSecretsRule returns a new rule
+func NewTerraformHardcodedSecretsRule() *TerraformHardcodedSecretsRule {
+	return &TerraformHardcodedSecretsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformHardcodedSecretsRule) Name() string {
+	return "terraform_hardcoded_secrets"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformHardcodedSecretsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformHardcodedSecretsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformHardcodedSecretsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether literal strings look like credentials
+func (r *TerraformHardcodedSecretsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformHardcodedSecretsRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	allowlist := make([]*regexp.Regexp, len(config.Allowlist))
+	for i, pattern := range config.Allowlist {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid allowlist pattern %q: %w", pattern, err)
+		}
+		allowlist[i] = re
+	}
+	allowed := func(value string) bool {
+		for _, re := range allowlist {
+			if re.MatchString(value) {
+				return true
+			}
+		}
+		return false
+	}
+
+	diags := runner.WalkAttributes(func(name string, expr hclsyntax.Expression) hcl.Diagnostics {
+		value, ok := literalString(expr)
+		if !ok || !secretCandidate(value) || allowed(value) {
+			return nil
+		}
+
+		var err error
+		if secretNameRegexp.MatchString(name) {
+			err = runner.EmitIssue(r, fmt.Sprintf("%q is set to a hardcoded value. Use a variable or a data source instead", name), expr.Range())
+		} else if description := secretDescription(value, !digestNameRegexp.MatchString(name)); description != "" {
+			err = runner.EmitIssue(r, fmt.Sprintf("Hardcoded value looks like %s", description), expr.Range())
+		}
+		if err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssue()",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	return r.checkVariableDefaults(runner, allowed)
+}
+
+// checkVariableDefaults checks variables whose name looks like a credential.
+// Variables without a default are expected to be given at runtime, so they are allowed if they are marked as sensitive.
+func (r *TerraformHardcodedSecretsRule) checkVariableDefaults(runner tflint.Runner, allowed func(string) bool) error {
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "default"}, {Name: "sensitive"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, variable := range body.Blocks {
+		if !secretNameRegexp.MatchString(variable.Labels[0]) {
+			continue
+		}
+		attr, exists := variable.Body.Attributes["default"]
+		if !exists {
+			if sensitive, exists := variable.Body.Attributes["sensitive"]; exists {
+				// If the value cannot be determined statically, it is assumed to be sensitive.
+				if val, known := staticBool(sensitive.Expr); val || !known {
+					continue
+				}
+			}
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("variable %q looks like a credential but is not marked as sensitive", variable.Labels[0]),
+				variable.DefRange,
+			); err != nil {
+				return err
+			}
+			continue
+		}
+		value, ok := literalString(attr.Expr)
+		if !ok || !secretCandidate(value) || allowed(value) || secretDescription(value, true) != "" {
+			// Values in well-known formats are already reported as arguments.
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("variable %q has a hardcoded default value. Remove the default and mark it as sensitive instead", variable.Labels[0]),
+			attr.Expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// literalString returns the value of the given expression if it is a string without any references or function calls.
+// JSON strings are parsed as templates in the same way as Terraform.
+func literalString(expr hcl.Expression) (string, bool) {
+	if json.IsJSONExpression(expr) {
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+			return "", false
+		}
+		template, diags := hclsyntax.ParseTemplate([]byte(val.AsString()), expr.Range().Filename, expr.Range().Start)
+		if diags.HasErrors() {
+			return "", false
+		}
+		expr = template
+	}
+
+	switch expr.(type) {
+	case *hclsyntax.TemplateExpr, *hclsyntax.LiteralValueExpr:
+	default:
+		return "", false
+	}
+	if len(expr.Variables()) > 0 {
+		return "", false
+	}
+
+	// Evaluating without a context fails if the expression calls functions.
+	val, diags := expr.Value(nil)
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false
+	}
+	return val.AsString(), true
+}
+
+// secretCandidate returns whether the value can be a credential.
+// Empty strings, booleans, numbers, and placeholders are not.
+func secretCandidate(value string) bool {
+	if value == "" || value == "true" || value == "false" || placeholderRegexp.MatchString(value) {
+		return false
+	}
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return false
+	}
+	return true
+}
+
+// secretDescription returns a description of the credential format the value matches, or an empty string.
+// If entropy is false, only well-known formats are checked.
+func secretDescription(value string, entropy bool) string {
+	for _, format := range secretFormats {
+		if format.regexp.MatchString(value) {
+			return format.description
+		}
+	}
+
+	if entropy && highEntropyCandidateRegexp.MatchString(value) && hasMixedCharacters(value) && shannonEntropy(value) >= highEntropyThreshold {
+		return "a secret (high entropy string)"
+	}
+
+	return ""
+}
+
+// hasMixedCharacters returns whether the value contains upper case letters, lower case letters, and digits
+func hasMixedCharacters(value string) bool {
+	return strings.IndexFunc(value, unicode.IsUpper) != -1 &&
+		strings.IndexFunc(value, unicode.IsLower) != -1 &&
+		strings.IndexFunc(value, unicode.IsDigit) != -1
+}
+
+// shannonEntropy returns the Shannon entropy of the value in bits per character
+func shannonEntropy(value string) float64 {
+	counts := map[rune]int{}
+	for _, c := range value {
+		counts[c]++
+	}
+
+	entropy := 0.0
+	length := float64(len(value))
+	for _, count := range counts {
+		p := float64(count) / length
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,274 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// TerraformLifecycleRule checks whether lifecycle blocks of resources are valid and safe
+type TerraformLifecycleRule struct {
+	tflint.DefaultRule
+}
+
+type terraformLifecycleRuleConfig struct {
+	// PreventDestroyTypes are glob patterns of resource types that must set prevent_destroy = true
+	PreventDestroyTypes []string `hclext:"prevent_destroy_types,optional"`
+	// CheckUnsetIgnoreChanges specifies whether ignore_changes entries for arguments not set in the resource are reported
+	CheckUnsetIgnoreChanges bool `hclext:"check_unset_ignore_changes,optional"`
+}
+
+// NewTerraformLifecycleRule returns a new rule
+func NewTerraformLifecycleRule() *TerraformLifecycleRule {
+	return &TerraformLifecycleRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformLifecycleRule) Name() string {
+	return "terraform_lifecycle"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformLifecycleRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformLifecycleRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformLifecycleRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks ignore_changes, replace_triggered_by, create_before_destroy, and prevent_destroy
+// in lifecycle blocks of managed resources
+func (r *TerraformLifecycleRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformLifecycleRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+
+	content, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+	resources := map[string]bool{}
+	for _, block := range content.Blocks {
+		resources[fmt.Sprintf("%s.%s", block.Labels[0], block.Labels[1])] = true
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			// Nested blocks cannot be distinguished from arguments in JSON syntax.
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			if block.Type != "resource" || len(block.Labels) != 2 {
+				continue
+			}
+
+			preventDestroy := false
+			for _, lifecycle := range block.Body.Blocks {
+				if lifecycle.Type != "lifecycle" {
+					continue
+				}
+
+				if err := r.checkIgnoreChanges(runner, block.Body, lifecycle.Body, config.CheckUnsetIgnoreChanges); err != nil {
+					return err
+				}
+				if err := r.checkReplaceTriggeredBy(runner, lifecycle.Body, resources); err != nil {
+					return err
+				}
+
+				prevent, exists := lifecycle.Body.Attributes["prevent_destroy"]
+				if !exists {
+					continue
+				}
+				if val, _ := staticBool(prevent.Expr); !val {
+					continue
+				}
+				preventDestroy = true
+
+				createBeforeDestroy, exists := lifecycle.Body.Attributes["create_before_destroy"]
+				if !exists {
+					continue
+				}
+				if val, _ := staticBool(createBeforeDestroy.Expr); val {
+					if err := runner.EmitIssue(
+						r,
+						`"create_before_destroy" has no effect with "prevent_destroy" because the resource cannot be replaced`,
+						hcl.RangeBetween(createBeforeDestroy.NameRange, prevent.NameRange),
+					); err != nil {
+						return err
+					}
+				}
+			}
+
+			if preventDestroy {
+				continue
+			}
+			for _, pattern := range config.PreventDestroyTypes {
+				matched, err := matchTypePattern(pattern, block.Labels[0])
+				if err != nil {
+					return err
+				}
+				if !matched {
+					continue
+				}
+				if err := runner.EmitIssue(
+					r,
+					fmt.Sprintf("Resources of type %q must set prevent_destroy = true in the lifecycle block", block.Labels[0]),
+					block.DefRange(),
+				); err != nil {
+					return err
+				}
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformLifecycleRule) checkIgnoreChanges(runner tflint.Runner, resource *hclsyntax.Body, lifecycle *hclsyntax.Body, checkUnset bool) error {
+	attr, exists := lifecycle.Attributes["ignore_changes"]
+	if !exists {
+		return nil
+	}
+
+	if hcl.ExprAsKeyword(attr.Expr) == "all" {
+		return runner.EmitIssue(
+			r,
+			`"ignore_changes = all" hides every change to the resource. List the arguments to ignore instead`,
+			attr.Expr.Range(),
+		)
+	}
+
+	// Ignoring arguments computed by providers or managed outside Terraform is a common pattern,
+	// so unset arguments are only reported if configured.
+	if !checkUnset {
+		return nil
+	}
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+
+	for _, expr := range tuple.Exprs {
+		traversal, diags := hcl.RelTraversalForExpr(expr)
+		if diags.HasErrors() || len(traversal) == 0 {
+			// Quoted references are reported by terraform_quoted_references.
+			continue
+		}
+		name, ok := traversal[0].(hcl.TraverseAttr)
+		if !ok || argumentSet(resource, name.Name) {
+			continue
+		}
+
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%q in ignore_changes is not set in this resource", name.Name),
+			expr.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformLifecycleRule) checkReplaceTriggeredBy(runner tflint.Runner, lifecycle *hclsyntax.Body, resources map[string]bool) error {
+	attr, exists := lifecycle.Attributes["replace_triggered_by"]
+	if !exists {
+		return nil
+	}
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+
+	for _, expr := range tuple.Exprs {
+		traversal, diags := hcl.AbsTraversalForExpr(expr)
+		if diags.HasErrors() {
+			continue
+		}
+		ref, diags := addrs.ParseRef(traversal)
+		if diags.HasErrors() {
+			continue
+		}
+
+		var resource addrs.Resource
+		switch subject := ref.Subject.(type) {
+		case addrs.Resource:
+			resource = subject
+		case addrs.ResourceInstance:
+			resource = subject.Resource
+		default:
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("replace_triggered_by only accepts references to managed resources. Use a terraform_data resource to trigger replacement from %q", subject.String()),
+				expr.Range(),
+			); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var message string
+		if resource.Mode != addrs.ManagedResourceMode {
+			message = fmt.Sprintf("replace_triggered_by only accepts references to managed resources, but %q is a data source", resource.String())
+		} else if !resources[resource.String()] {
+			message = fmt.Sprintf("%q in replace_triggered_by is not declared in this module", resource.String())
+		} else {
+			continue
+		}
+		if err := runner.EmitIssue(r, message, expr.Range()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// argumentSet returns whether the body sets an argument or nested block with the given name
+func argumentSet(body *hclsyntax.Body, name string) bool {
+	if _, exists := body.Attributes[name]; exists {
+		return true
+	}
+	for _, block := range body.Blocks {
+		if block.Type == name {
+			return true
+		}
+		if block.Type == "dynamic" && len(block.Labels) == 1 && block.Labels[0] == name {
+			return true
+		}
+	}
+	return false
+}
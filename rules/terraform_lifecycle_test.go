@@ -0,0 +1,240 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformLifecycleRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "valid lifecycle",
+			Content: `
+resource "terraform_data" "revision" {
+  input = var.revision
+}
+
+resource "aws_instance" "web" {
+  ami  = var.ami
+  tags = var.tags
+
+  dynamic "ebs_block_device" {
+    for_each = var.volumes
+    content {}
+  }
+
+  lifecycle {
+    create_before_destroy = true
+    ignore_changes        = [ami, tags["Name"], ebs_block_device]
+    replace_triggered_by  = [terraform_data.revision, terraform_data.revision.output]
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "ignore_changes",
+			Content: `
+resource "aws_instance" "web" {
+  ami = var.ami
+
+  lifecycle {
+    ignore_changes = [ami, user_data]
+  }
+}
+
+resource "aws_instance" "db" {
+  lifecycle {
+    ignore_changes = all
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `"ignore_changes = all" hides every change to the resource. List the arguments to ignore instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 22},
+						End:      hcl.Pos{Line: 12, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "unset ignore_changes",
+			Config: `
+rule "terraform_lifecycle" {
+  enabled                    = true
+  check_unset_ignore_changes = true
+}`,
+			Content: `
+resource "aws_instance" "web" {
+  ami = var.ami
+
+  lifecycle {
+    ignore_changes = [ami, user_data]
+  }
+}
+
+resource "aws_instance" "db" {
+  lifecycle {
+    ignore_changes = all
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `"user_data" in ignore_changes is not set in this resource`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 28},
+						End:      hcl.Pos{Line: 6, Column: 37},
+					},
+				},
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `"ignore_changes = all" hides every change to the resource. List the arguments to ignore instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 22},
+						End:      hcl.Pos{Line: 12, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "replace_triggered_by",
+			Content: `
+resource "aws_instance" "web" {
+  lifecycle {
+    replace_triggered_by = [var.revision, data.aws_ami.web.id, aws_launch_template.web]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `replace_triggered_by only accepts references to managed resources. Use a terraform_data resource to trigger replacement from "var.revision"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 29},
+						End:      hcl.Pos{Line: 4, Column: 41},
+					},
+				},
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `replace_triggered_by only accepts references to managed resources, but "data.aws_ami.web" is a data source`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 43},
+						End:      hcl.Pos{Line: 4, Column: 62},
+					},
+				},
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `"aws_launch_template.web" in replace_triggered_by is not declared in this module`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 64},
+						End:      hcl.Pos{Line: 4, Column: 87},
+					},
+				},
+			},
+		},
+		{
+			Name: "create_before_destroy with prevent_destroy",
+			Content: `
+resource "aws_db_instance" "main" {
+  lifecycle {
+    create_before_destroy = true
+    prevent_destroy       = true
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `"create_before_destroy" has no effect with "prevent_destroy" because the resource cannot be replaced`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 5},
+						End:      hcl.Pos{Line: 5, Column: 20},
+					},
+				},
+			},
+		},
+		{
+			Name: "prevent_destroy_types",
+			Config: `
+rule "terraform_lifecycle" {
+  enabled               = true
+  prevent_destroy_types = ["aws_db_*", "aws_s3_bucket"]
+}`,
+			Content: `
+resource "aws_db_instance" "main" {
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+
+resource "aws_s3_bucket" "logs" {
+  lifecycle {
+    prevent_destroy = false
+  }
+}
+
+resource "aws_s3_bucket" "assets" {}
+
+resource "aws_instance" "web" {}
+
+resource "aws_db_cluster" "main" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `Resources of type "aws_s3_bucket" must set prevent_destroy = true in the lifecycle block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 32},
+					},
+				},
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `Resources of type "aws_s3_bucket" must set prevent_destroy = true in the lifecycle block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 1},
+						End:      hcl.Pos{Line: 14, Column: 34},
+					},
+				},
+				{
+					Rule:    NewTerraformLifecycleRule(),
+					Message: `Resources of type "aws_db_cluster" must set prevent_destroy = true in the lifecycle block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 18, Column: 1},
+						End:      hcl.Pos{Line: 18, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformLifecycleRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
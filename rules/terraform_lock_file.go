@@ -0,0 +1,209 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformLockFileRule checks whether the dependency lock file is consistent with required_providers
+type TerraformLockFileRule struct {
+	tflint.DefaultRule
+}
+
+type terraformLockFileRuleConfig struct {
+	Platforms []string `hclext:"platforms,optional"`
+}
+
+// NewTerraformLockFileRule returns a new rule
+func NewTerraformLockFileRule() *TerraformLockFileRule {
+	return &TerraformLockFileRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformLockFileRule) Name() string {
+	return "terraform_lock_file"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformLockFileRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformLockFileRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformLockFileRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the lock file in the module directory against required_providers
+func (r *TerraformLockFileRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformLockFileRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	var dir string
+	for name := range files {
+		dir = filepath.Dir(name)
+		break
+	}
+	if dir == "" {
+		return nil
+	}
+
+	locked, diags := terraform.LoadLockFile(dir)
+	if diags.HasErrors() {
+		return diags
+	}
+	if locked == nil {
+		// The module has not been initialized, or the lock file is not committed.
+		return nil
+	}
+
+	requiredProviders, diags := runner.GetRequiredProviders()
+	if diags.HasErrors() {
+		return diags
+	}
+	names := make([]string, 0, len(requiredProviders))
+	for name := range requiredProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	required := map[string]bool{}
+	for _, name := range names {
+		provider := terraform.DecodeRequiredProvider(requiredProviders[name])
+		addr, err := tfaddr.ParseProviderSource(provider.Source)
+		if err != nil || addr.IsBuiltIn() {
+			continue
+		}
+		required[addr.String()] = true
+
+		if err := r.checkProvider(runner, provider, locked[addr.String()], config.Platforms); err != nil {
+			return err
+		}
+	}
+
+	calls, diags := runner.GetModuleCalls()
+	if diags.HasErrors() {
+		return diags
+	}
+	if len(calls) > 0 {
+		// Providers required by child modules are also locked, so stale entries cannot be determined.
+		return nil
+	}
+
+	providerRefs, diags := runner.GetProviderRefs()
+	if diags.HasErrors() {
+		return diags
+	}
+	for name := range providerRefs {
+		// Providers without required_providers entries are implicitly "hashicorp/<name>".
+		if _, exists := requiredProviders[name]; exists || name == "terraform" {
+			continue
+		}
+		addr, err := tfaddr.ParseProviderSource("hashicorp/" + name)
+		if err != nil {
+			continue
+		}
+		required[addr.String()] = true
+	}
+
+	sources := make([]string, 0, len(locked))
+	for source := range locked {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	for _, source := range sources {
+		if required[source] {
+			continue
+		}
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Provider %q is locked in %s but is no longer required", source, terraform.LockFileName),
+			locked[source].DefRange,
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformLockFileRule) checkProvider(runner tflint.Runner, provider *terraform.RequiredProvider, locked *terraform.LockedProvider, platforms []string) error {
+	if locked == nil {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("Provider %q is not locked in %s", provider.Name, terraform.LockFileName),
+			provider.Attribute.Expr.Range(),
+		)
+	}
+
+	if provider.VersionExpr != nil {
+		val, diags := provider.VersionExpr.Value(nil)
+		if !diags.HasErrors() && val.Type() == cty.String && val.IsKnown() && !val.IsNull() {
+			constraints, err := version.NewConstraint(val.AsString())
+			if err == nil && !constraints.Check(locked.Version) {
+				return runner.EmitIssue(
+					r,
+					fmt.Sprintf("Locked version %s of provider %q does not satisfy the constraint %q", locked.Version, provider.Name, val.AsString()),
+					provider.VersionExpr.Range(),
+				)
+			}
+		}
+	}
+
+	if len(platforms) > 0 {
+		// h1: hashes do not record which platform they belong to, so this is a heuristic:
+		// a provider with fewer h1: hashes than platforms cannot be locked for all of them,
+		// but one with enough hashes may still miss a platform.
+		hashes := 0
+		for _, hash := range locked.Hashes {
+			if strings.HasPrefix(hash, "h1:") {
+				hashes++
+			}
+		}
+		if hashes < len(platforms) {
+			flags := make([]string, len(platforms))
+			for i, platform := range platforms {
+				flags[i] = "-platform=" + platform
+			}
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf(`Provider %q is not locked for all platforms. Run "terraform providers lock %s"`, provider.Name, strings.Join(flags, " ")),
+				provider.Attribute.Expr.Range(),
+			)
+		}
+	}
+
+	return nil
+}
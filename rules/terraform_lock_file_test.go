@@ -0,0 +1,207 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformLockFileRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Lock     string
+		Config   string
+		Expected func(dir string) helper.Issues
+	}{
+		{
+			Name: "no lock file",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}`,
+			Expected: func(dir string) helper.Issues { return helper.Issues{} },
+		},
+		{
+			Name: "consistent",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    terraform = {
+      source = "terraform.io/builtin/terraform"
+    }
+  }
+}
+
+resource "random_id" "main" {}`,
+			Lock: `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}`,
+			Expected: func(dir string) helper.Issues { return helper.Issues{} },
+		},
+		{
+			Name: "not locked",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+  }
+}`,
+			Lock: `
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformLockFileRule(),
+						Message: `Provider "aws" is not locked in .terraform.lock.hcl`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 11},
+							End:      hcl.Pos{Line: 6, Column: 6},
+						},
+					},
+					{
+						Rule:    NewTerraformLockFileRule(),
+						Message: `Provider "registry.terraform.io/hashicorp/random" is locked in .terraform.lock.hcl but is no longer required`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, ".terraform.lock.hcl"),
+							Start:    hcl.Pos{Line: 2, Column: 1},
+							End:      hcl.Pos{Line: 2, Column: 50},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "unsatisfied constraint",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}`,
+			Lock: `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "4.67.0"
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformLockFileRule(),
+						Message: `Locked version 4.67.0 of provider "aws" does not satisfy the constraint "~> 5.0"`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 6, Column: 17},
+							End:      hcl.Pos{Line: 6, Column: 25},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "stale entries with module calls",
+			Content: `
+module "network" {
+  source = "./network"
+}`,
+			Lock: `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+}`,
+			Expected: func(dir string) helper.Issues { return helper.Issues{} },
+		},
+		{
+			Name: "platforms",
+			Config: `
+rule "terraform_lock_file" {
+  enabled   = true
+  platforms = ["linux_amd64", "darwin_arm64"]
+}`,
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}`,
+			Lock: `
+provider "registry.terraform.io/hashicorp/aws" {
+  version = "5.31.0"
+  hashes = [
+    "h1:linux=",
+    "zh:abc",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+  hashes = [
+    "h1:linux=",
+    "h1:darwin=",
+    "zh:abc",
+  ]
+}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformLockFileRule(),
+						Message: `Provider "aws" is not locked for all platforms. Run "terraform providers lock -platform=linux_amd64 -platform=darwin_arm64"`,
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 4, Column: 11},
+							End:      hcl.Pos{Line: 6, Column: 6},
+						},
+					},
+				}
+			},
+		},
+	}
+
+	rule := NewTerraformLockFileRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			if tc.Lock != "" {
+				if err := os.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), []byte(tc.Lock), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			runner := testRunner(t, map[string]string{filepath.Join(dir, "main.tf"): tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected(dir), runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
@@ -2,12 +2,18 @@ package rules
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/logger"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
 	"github.com/zclconf/go-cty/cty"
 	"github.com/zclconf/go-cty/cty/convert"
 )
@@ -47,17 +53,322 @@ func (r *TerraformMapDuplicateKeysRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	evaluate := func(expr hcl.Expression) (cty.Value, error) {
+		var val cty.Value
+		err := runner.EvaluateExpr(expr, &val, nil)
+		return val, err
+	}
 	diags := runner.WalkExpressions(tflint.ExprWalkFunc(func(e hcl.Expression) hcl.Diagnostics {
-		return r.checkObjectConsExpr(e, runner)
+		return r.checkObjectConsExpr(e, runner, evaluate)
 	}))
 	if diags.HasErrors() {
 		return diags
 	}
 
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	filenames := make([]string, 0, len(files))
+	for name := range files {
+		filenames = append(filenames, name)
+	}
+	sort.Strings(filenames)
+
+	for _, name := range filenames {
+		if !strings.HasSuffix(name, ".tf.json") {
+			continue
+		}
+		if err := r.checkJSONFile(runner, name, files[name].Bytes, false); err != nil {
+			return err
+		}
+	}
+
+	if err := r.checkLocals(runner, filenames, files); err != nil {
+		return err
+	}
+
+	// Variable definition files can only assign declared variables,
+	// so they are read only when the module declares any.
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "variable", LabelNames: []string{"name"}}},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+	if len(filenames) == 0 || len(body.Blocks) == 0 {
+		return nil
+	}
+	return r.checkVariableDefinitionFiles(runner, filepath.Dir(filenames[0]))
+}
+
+// checkVariableDefinitionFiles checks *.tfvars and *.tfvars.json files in the module directory.
+// These files are not loaded as module files, so they are read from the directory.
+func (r *TerraformMapDuplicateKeysRule) checkVariableDefinitionFiles(runner tflint.Runner, dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	// Variable definition files cannot refer to anything, so keys are evaluated without context.
+	evaluate := func(expr hcl.Expression) (cty.Value, error) {
+		val, diags := expr.Value(nil)
+		if diags.HasErrors() {
+			return cty.NilVal, diags
+		}
+		return val, nil
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+
+		switch {
+		case strings.HasSuffix(filename, ".tfvars"):
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+			if diags.HasErrors() {
+				logger.Debug("Failed to parse variable definitions file. The file will be ignored", "filename", filename, "error", diags.Error())
+				continue
+			}
+			diags = hclsyntax.VisitAll(file.Body.(*hclsyntax.Body), func(n hclsyntax.Node) hcl.Diagnostics {
+				if expr, ok := n.(hclsyntax.Expression); ok {
+					return r.checkObjectConsExpr(expr, runner, evaluate)
+				}
+				return nil
+			})
+			if diags.HasErrors() {
+				return diags
+			}
+		case strings.HasSuffix(filename, ".tfvars.json"):
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				return err
+			}
+			if err := r.checkJSONFile(runner, filename, src, true); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// jsonBlockLabels is the number of labels of top-level blocks in JSON syntax.
+// Objects at the levels of block types and labels can have duplicate properties to declare multiple blocks,
+// so only the objects in the values of block bodies are checked.
+// @see https://github.com/hashicorp/hcl/blob/v2.24.0/json/spec.md#blocks
+var jsonBlockLabels = map[string]int{
+	"resource":  2,
+	"data":      2,
+	"ephemeral": 2,
+	"module":    1,
+	"provider":  1,
+	"variable":  1,
+	"output":    1,
+	"check":     1,
+}
+
+// jsonNestedBlockLabels is the number of labels of nested blocks in JSON syntax.
+// Like top-level blocks, the objects at the levels of their labels can have duplicate properties.
+var jsonNestedBlockLabels = map[string]int{
+	"provisioner": 1,
+	"dynamic":     1,
+	"backend":     1,
+}
+
+// checkJSONFile checks objects in the JSON file by scanning its token stream,
+// since duplicate properties are dropped when decoded as expressions.
+// If the file is a variable definitions file, each property of the root object is a variable value.
+func (r *TerraformMapDuplicateKeysRule) checkJSONFile(runner tflint.Runner, filename string, src []byte, tfvars bool) error {
+	root, err := terraform.ParseJSON(src, filename)
+	if err != nil {
+		logger.Debug("Failed to parse JSON. The file will be ignored", "filename", filename, "error", err.Error())
+		return nil
+	}
+
+	for _, prop := range root.Properties {
+		if tfvars {
+			if err := r.checkJSONValue(runner, prop.Value, false); err != nil {
+				return err
+			}
+			continue
+		}
+		if prop.Name == "locals" {
+			// Local values cannot be nested blocks. Duplicate names are reported by checkLocals.
+			for _, locals := range append([]*terraform.JSONValue{prop.Value}, prop.Value.Elements...) {
+				for _, local := range locals.Properties {
+					if err := r.checkJSONValue(runner, local.Value, false); err != nil {
+						return err
+					}
+				}
+			}
+			continue
+		}
+		if err := r.checkJSONBlocks(runner, prop.Value, jsonBlockLabels[prop.Name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkJSONBlocks checks the values in block bodies below the given number of label levels.
+// Properties of block bodies can be nested blocks, so only their values are checked.
+func (r *TerraformMapDuplicateKeysRule) checkJSONBlocks(runner tflint.Runner, value *terraform.JSONValue, labels int) error {
+	for _, elem := range value.Elements {
+		if err := r.checkJSONBlocks(runner, elem, labels); err != nil {
+			return err
+		}
+	}
+
+	for _, prop := range value.Properties {
+		var err error
+		switch {
+		case labels > 0:
+			err = r.checkJSONBlocks(runner, prop.Value, labels-1)
+		case jsonNestedBlockLabels[prop.Name] > 0:
+			err = r.checkJSONBlocks(runner, prop.Value, jsonNestedBlockLabels[prop.Name])
+		default:
+			err = r.checkJSONValue(runner, prop.Value, true)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkJSONValue checks all objects in the value for duplicate properties.
+// If the value is in a block body, it may be a nested block, so nested blocks with labels are checked as blocks.
+func (r *TerraformMapDuplicateKeysRule) checkJSONValue(runner tflint.Runner, value *terraform.JSONValue, inBlock bool) error {
+	keys := make(map[string]hcl.Range)
+
+	for _, prop := range value.Properties {
+		if declRange, exists := keys[prop.Name]; exists {
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("Duplicate key: %q, first defined at %s", prop.Name, declRange),
+				prop.NameRange,
+			); err != nil {
+				return err
+			}
+		} else {
+			keys[prop.Name] = prop.NameRange
+		}
+
+		var err error
+		if inBlock && jsonNestedBlockLabels[prop.Name] > 0 {
+			err = r.checkJSONBlocks(runner, prop.Value, jsonNestedBlockLabels[prop.Name])
+		} else {
+			err = r.checkJSONValue(runner, prop.Value, inBlock)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, elem := range value.Elements {
+		if err := r.checkJSONValue(runner, elem, inBlock); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkLocals checks whether local values with the same name are declared more than once,
+// in multiple "locals" blocks or in the same "locals" object in JSON syntax.
+// Each declaration is reported with the locations of the others.
+func (r *TerraformMapDuplicateKeysRule) checkLocals(runner tflint.Runner, filenames []string, files map[string]*hcl.File) error {
+	declarations := map[string][]hcl.Range{}
+	names := []string{}
+
+	declare := func(name string, rng hcl.Range) {
+		if _, exists := declarations[name]; !exists {
+			names = append(names, name)
+		}
+		declarations[name] = append(declarations[name], rng)
+	}
+
+	for _, filename := range filenames {
+		if strings.HasSuffix(filename, ".tf.json") {
+			// Duplicate properties in a "locals" object are not decoded as attributes, so the raw JSON is scanned.
+			root, err := terraform.ParseJSON(files[filename].Bytes, filename)
+			if err != nil {
+				continue
+			}
+			for _, prop := range root.Properties {
+				if prop.Name != "locals" {
+					continue
+				}
+				for _, locals := range append([]*terraform.JSONValue{prop.Value}, prop.Value.Elements...) {
+					for _, local := range locals.Properties {
+						declare(local.Name, local.NameRange)
+					}
+				}
+			}
+			continue
+		}
+
+		content, _, diags := files[filename].Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "locals"}},
+		})
+		if diags.HasErrors() {
+			return diags
+		}
+
+		for _, block := range content.Blocks {
+			attrs, diags := block.Body.JustAttributes()
+			if diags.HasErrors() {
+				return diags
+			}
+
+			blockNames := make([]string, 0, len(attrs))
+			for name := range attrs {
+				blockNames = append(blockNames, name)
+			}
+			sort.Slice(blockNames, func(i, j int) bool {
+				return attrs[blockNames[i]].NameRange.Start.Byte < attrs[blockNames[j]].NameRange.Start.Byte
+			})
+
+			for _, name := range blockNames {
+				declare(name, attrs[name].NameRange)
+			}
+		}
+	}
+
+	for _, name := range names {
+		ranges := declarations[name]
+		if len(ranges) < 2 {
+			continue
+		}
+
+		for i, rng := range ranges {
+			others := []string{}
+			for j, other := range ranges {
+				if i != j {
+					others = append(others, other.String())
+				}
+			}
+
+			if err := runner.EmitIssue(
+				r,
+				fmt.Sprintf("Local value %q is also defined at %s", name, strings.Join(others, ", ")),
+				rng,
+			); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
-func (r *TerraformMapDuplicateKeysRule) checkObjectConsExpr(e hcl.Expression, runner tflint.Runner) hcl.Diagnostics {
+func (r *TerraformMapDuplicateKeysRule) checkObjectConsExpr(e hcl.Expression, runner tflint.Runner, evaluate func(hcl.Expression) (cty.Value, error)) hcl.Diagnostics {
 	objExpr, ok := e.(*hclsyntax.ObjectConsExpr)
 	if !ok {
 		return nil
@@ -78,7 +389,8 @@ func (r *TerraformMapDuplicateKeysRule) checkObjectConsExpr(e hcl.Expression, ru
 		if keyword := hcl.ExprAsKeyword(expr.Wrapped); !expr.ForceNonLiteral && keyword != "" {
 			val = cty.StringVal(keyword)
 		} else {
-			err := runner.EvaluateExpr(expr, &val, nil)
+			var err error
+			val, err = evaluate(expr)
 			if err != nil {
 				// When a key fails to evaluate, ignore the key and continue processing rather than terminating with an error.
 				// This is due to a limitation that expressions with different scopes, such as for expressions, cannot be evaluated.
@@ -1,6 +1,9 @@
 package rules
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
@@ -221,3 +224,268 @@ resource "null_resource" "test" {
 		})
 	}
 }
+
+func Test_TerraformMapDuplicateKeysJSON(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "duplicate keys in argument",
+			Content: `{
+  "resource": {
+    "null_resource": {
+      "test": {
+        "triggers": {"a": "b", "a": "c"}
+      }
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMapDuplicateKeysRule(),
+					Message: `Duplicate key: "a", first defined at module.tf.json:5,22-25`,
+					Range: hcl.Range{
+						Filename: "module.tf.json",
+						Start:    hcl.Pos{Line: 5, Column: 32},
+						End:      hcl.Pos{Line: 5, Column: 35},
+					},
+				},
+			},
+		},
+		{
+			Name: "duplicate keys in nested object",
+			Content: `{
+  "locals": {
+    "settings": [{"nested": {"a": 1, "a": 2}}]
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMapDuplicateKeysRule(),
+					Message: `Duplicate key: "a", first defined at module.tf.json:3,30-33`,
+					Range: hcl.Range{
+						Filename: "module.tf.json",
+						Start:    hcl.Pos{Line: 3, Column: 38},
+						End:      hcl.Pos{Line: 3, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "duplicate blocks",
+			Content: `{
+  "resource": {
+    "null_resource": {"a": {}},
+    "null_resource": {"b": {}}
+  },
+  "variable": {
+    "foo": {"default": 1},
+    "foo": {"default": 2}
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "duplicate nested blocks",
+			Content: `{
+  "resource": {
+    "null_resource": {
+      "a": {
+        "provisioner": {
+          "local-exec": {"command": "echo a"},
+          "local-exec": {"command": "echo b"}
+        },
+        "dynamic": {
+          "setting": {"for_each": [], "content": {}},
+          "setting": {"for_each": [], "content": {}}
+        },
+        "triggers": {"a": 1, "a": 2}
+      }
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMapDuplicateKeysRule(),
+					Message: `Duplicate key: "a", first defined at module.tf.json:13,22-25`,
+					Range: hcl.Range{
+						Filename: "module.tf.json",
+						Start:    hcl.Pos{Line: 13, Column: 30},
+						End:      hcl.Pos{Line: 13, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformMapDuplicateKeysRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, map[string]string{"module.tf.json": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
+
+func Test_TerraformMapDuplicateKeysVariableDefinitionFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"terraform.tfvars": `
+tags = {
+  env = "dev"
+  env = "prod"
+}
+`,
+		"dev.auto.tfvars.json": `{
+  "tags": {"env": "dev", "env": "prod"}
+}`,
+		"other.txt": `{"a": 1, "a": 2}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	runner := testRunner(t, map[string]string{filepath.Join(dir, "main.tf"): `variable "tags" {}`})
+
+	if err := NewTerraformMapDuplicateKeysRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: fmt.Sprintf(`Duplicate key: "env", first defined at %s:3,3-6`, filepath.Join(dir, "terraform.tfvars")),
+			Range: hcl.Range{
+				Filename: filepath.Join(dir, "terraform.tfvars"),
+				Start:    hcl.Pos{Line: 4, Column: 3},
+				End:      hcl.Pos{Line: 4, Column: 6},
+			},
+		},
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: fmt.Sprintf(`Duplicate key: "env", first defined at %s:2,12-17`, filepath.Join(dir, "dev.auto.tfvars.json")),
+			Range: hcl.Range{
+				Filename: filepath.Join(dir, "dev.auto.tfvars.json"),
+				Start:    hcl.Pos{Line: 2, Column: 26},
+				End:      hcl.Pos{Line: 2, Column: 31},
+			},
+		},
+	}, runner.Runner.(*helper.Runner).Issues)
+}
+
+func Test_TerraformMapDuplicateKeysVariableDefinitionFilesWithoutVariables(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "terraform.tfvars"), []byte(`tags = { env = "dev", env = "prod" }`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// Variable definition files are not read when the module declares no variables.
+	runner := testRunner(t, map[string]string{filepath.Join(dir, "main.tf"): `locals {}`})
+
+	if err := NewTerraformMapDuplicateKeysRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{}, runner.Runner.(*helper.Runner).Issues)
+}
+
+func Test_TerraformMapDuplicateKeysLocals(t *testing.T) {
+	runner := testRunner(t, map[string]string{
+		"main.tf": `
+locals {
+  name = "foo"
+  tags = {}
+}`,
+		"network.tf": `
+locals {
+  cidr = "10.0.0.0/16"
+  name = "bar"
+}`,
+		"other.tf.json": `{
+  "locals": {
+    "name": "baz"
+  }
+}`,
+	})
+
+	if err := NewTerraformMapDuplicateKeysRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: `Local value "name" is also defined at network.tf:4,3-7, other.tf.json:3,5-11`,
+			Range: hcl.Range{
+				Filename: "main.tf",
+				Start:    hcl.Pos{Line: 3, Column: 3},
+				End:      hcl.Pos{Line: 3, Column: 7},
+			},
+		},
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: `Local value "name" is also defined at main.tf:3,3-7, other.tf.json:3,5-11`,
+			Range: hcl.Range{
+				Filename: "network.tf",
+				Start:    hcl.Pos{Line: 4, Column: 3},
+				End:      hcl.Pos{Line: 4, Column: 7},
+			},
+		},
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: `Local value "name" is also defined at main.tf:3,3-7, network.tf:4,3-7`,
+			Range: hcl.Range{
+				Filename: "other.tf.json",
+				Start:    hcl.Pos{Line: 3, Column: 5},
+				End:      hcl.Pos{Line: 3, Column: 11},
+			},
+		},
+	}, runner.Runner.(*helper.Runner).Issues)
+}
+
+func Test_TerraformMapDuplicateKeysLocalsInJSON(t *testing.T) {
+	runner := testRunner(t, map[string]string{
+		"main.tf.json": `{
+  "locals": {
+    "name": "foo",
+    "tags": {},
+    "name": "bar"
+  }
+}`,
+	})
+
+	if err := NewTerraformMapDuplicateKeysRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertIssues(t, helper.Issues{
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: `Local value "name" is also defined at main.tf.json:5,5-11`,
+			Range: hcl.Range{
+				Filename: "main.tf.json",
+				Start:    hcl.Pos{Line: 3, Column: 5},
+				End:      hcl.Pos{Line: 3, Column: 11},
+			},
+		},
+		{
+			Rule:    NewTerraformMapDuplicateKeysRule(),
+			Message: `Local value "name" is also defined at main.tf.json:3,5-11`,
+			Range: hcl.Range{
+				Filename: "main.tf.json",
+				Start:    hcl.Pos{Line: 5, Column: 5},
+				End:      hcl.Pos{Line: 5, Column: 11},
+			},
+		},
+	}, runner.Runner.(*helper.Runner).Issues)
+}
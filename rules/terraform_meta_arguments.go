@@ -0,0 +1,181 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/lang"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// repeatableBlockTypes are block types that accept count and for_each
+var repeatableBlockTypes = map[string]bool{
+	"resource":  true,
+	"data":      true,
+	"ephemeral": true,
+	"module":    true,
+}
+
+// selfBlockTypes are nested block types in which self is available, keyed by the top-level block type
+var selfBlockTypes = map[string]map[string]bool{
+	"resource": {
+		"provisioner":   true,
+		"connection":    true,
+		"postcondition": true,
+	},
+	"data": {
+		"postcondition": true,
+	},
+	"ephemeral": {
+		"postcondition": true,
+	},
+}
+
+// TerraformMetaArgumentsRule checks whether count, for_each, each, and self are used in valid contexts
+type TerraformMetaArgumentsRule struct {
+	tflint.DefaultRule
+}
+
+// metaArgumentScope represents the objects available in a block
+type metaArgumentScope struct {
+	count   bool
+	forEach bool
+	self    bool
+}
+
+// NewTerraformMetaArgumentsRule returns a new rule
+func NewTerraformMetaArgumentsRule() *TerraformMetaArgumentsRule {
+	return &TerraformMetaArgumentsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformMetaArgumentsRule) Name() string {
+	return "terraform_meta_arguments"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformMetaArgumentsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformMetaArgumentsRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *TerraformMetaArgumentsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether count.index, each.key, each.value, and self are referenced where they are available,
+// and whether count and for_each are set together
+func (r *TerraformMetaArgumentsRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			// Nested blocks cannot be distinguished from arguments in JSON syntax.
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "resource", "data", "ephemeral", "module", "output", "locals":
+			default:
+				continue
+			}
+
+			scope := metaArgumentScope{}
+			if repeatableBlockTypes[block.Type] {
+				count, hasCount := block.Body.Attributes["count"]
+				forEach, hasForEach := block.Body.Attributes["for_each"]
+				scope.count = hasCount
+				scope.forEach = hasForEach
+
+				if hasCount && hasForEach {
+					if err := runner.EmitIssue(
+						r,
+						`"count" and "for_each" cannot be set together`,
+						hcl.RangeBetween(count.NameRange, forEach.NameRange),
+					); err != nil {
+						return err
+					}
+				}
+			}
+
+			if err := r.checkBody(runner, block.Type, block.Body, scope); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformMetaArgumentsRule) checkBody(runner tflint.Runner, blockType string, body *hclsyntax.Body, scope metaArgumentScope) error {
+	for _, attr := range body.Attributes {
+		for _, ref := range lang.ReferencesInExpr(attr.Expr) {
+			if message := scope.unavailableReason(ref.Subject); message != "" {
+				if err := runner.EmitIssue(r, message, ref.SourceRange); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		nested := scope
+		if selfBlockTypes[blockType][block.Type] {
+			nested.self = true
+		}
+		if err := r.checkBody(runner, blockType, block.Body, nested); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// unavailableReason returns the reason why the referenced object is not available in the scope,
+// or an empty string if it is available
+func (s metaArgumentScope) unavailableReason(subject addrs.Referenceable) string {
+	switch sub := subject.(type) {
+	case addrs.CountAttr:
+		if s.count {
+			return ""
+		}
+		if s.forEach {
+			return fmt.Sprintf(`%q is not available in a block with "for_each". Use "each.key" or "each.value" instead`, sub.String())
+		}
+		return fmt.Sprintf(`%q is only available in blocks with "count"`, sub.String())
+	case addrs.ForEachAttr:
+		if s.forEach {
+			return ""
+		}
+		if s.count {
+			return fmt.Sprintf(`%q is not available in a block with "count". Use "count.index" instead`, sub.String())
+		}
+		return fmt.Sprintf(`%q is only available in blocks with "for_each"`, sub.String())
+	}
+
+	if subject == addrs.Self && !s.self {
+		return `"self" is only available in provisioner and connection blocks of resources, and in postcondition blocks`
+	}
+	return ""
+}
@@ -0,0 +1,200 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformMetaArgumentsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Expected helper.Issues
+	}{
+		{
+			Name: "valid references",
+			Content: `
+resource "aws_instance" "count" {
+  count = 2
+  tags  = { Name = "web-${count.index}" }
+
+  provisioner "local-exec" {
+    command = "echo ${self.private_ip}"
+  }
+
+  lifecycle {
+    postcondition {
+      condition     = self.ami != ""
+      error_message = "ami is required"
+    }
+  }
+}
+
+module "for_each" {
+  source   = "./module"
+  for_each = var.instances
+  name     = each.key
+  size     = each.value
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "count.index in a block with for_each",
+			Content: `
+resource "aws_instance" "web" {
+  for_each = var.instances
+  tags     = { Name = "web-${count.index}" }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"count.index" is not available in a block with "for_each". Use "each.key" or "each.value" instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 30},
+						End:      hcl.Pos{Line: 4, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name: "each in blocks with count or without repetition",
+			Content: `
+resource "aws_instance" "web" {
+  count = 2
+
+  ebs_block_device {
+    device_name = each.key
+  }
+}
+
+output "name" {
+  value = each.value
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"each.key" is not available in a block with "count". Use "count.index" instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 19},
+						End:      hcl.Pos{Line: 6, Column: 27},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"each.value" is only available in blocks with "for_each"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 11},
+						End:      hcl.Pos{Line: 11, Column: 21},
+					},
+				},
+			},
+		},
+		{
+			Name: "self outside provisioners",
+			Content: `
+resource "aws_instance" "web" {
+  tags = { Name = self.id }
+}
+
+locals {
+  index = count.index
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"self" is only available in provisioner and connection blocks of resources, and in postcondition blocks`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 19},
+						End:      hcl.Pos{Line: 3, Column: 23},
+					},
+				},
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"count.index" is only available in blocks with "count"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 11},
+						End:      hcl.Pos{Line: 7, Column: 22},
+					},
+				},
+			},
+		},
+		{
+			Name: "self in postconditions of data sources and ephemeral resources",
+			Content: `
+data "aws_ami" "main" {
+  lifecycle {
+    precondition {
+      condition     = self.architecture == "x86_64"
+      error_message = "The AMI must be for x86_64."
+    }
+    postcondition {
+      condition     = self.architecture == "x86_64"
+      error_message = "The AMI must be for x86_64."
+    }
+  }
+}
+
+ephemeral "random_password" "main" {
+  length = 16
+
+  lifecycle {
+    postcondition {
+      condition     = length(self.result) == 16
+      error_message = "The password must be 16 characters."
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"self" is only available in provisioner and connection blocks of resources, and in postcondition blocks`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 23},
+						End:      hcl.Pos{Line: 5, Column: 27},
+					},
+				},
+			},
+		},
+		{
+			Name: "count and for_each together",
+			Content: `
+resource "aws_instance" "web" {
+  count    = 2
+  for_each = var.instances
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformMetaArgumentsRule(),
+					Message: `"count" and "for_each" cannot be set together`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 11},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformMetaArgumentsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"main.tf": tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
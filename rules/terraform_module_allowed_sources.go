@@ -0,0 +1,148 @@
+package rules
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/go-getter"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// TerraformModuleAllowedSourcesRule checks whether module sources match the allowed patterns
+type TerraformModuleAllowedSourcesRule struct {
+	tflint.DefaultRule
+}
+
+type terraformModuleAllowedSourcesRuleConfig struct {
+	Allowed []string `hclext:"allowed,optional"`
+}
+
+// NewTerraformModuleAllowedSourcesRule returns a new rule
+func NewTerraformModuleAllowedSourcesRule() *TerraformModuleAllowedSourcesRule {
+	return &TerraformModuleAllowedSourcesRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformModuleAllowedSourcesRule) Name() string {
+	return "terraform_module_allowed_sources"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformModuleAllowedSourcesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformModuleAllowedSourcesRule) Severity() tflint.Severity {
+	return tflint.ERROR
+}
+
+// Link returns the rule reference link
+func (r *TerraformModuleAllowedSourcesRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether module sources match the allowed patterns
+func (r *TerraformModuleAllowedSourcesRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformModuleAllowedSourcesRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	if len(config.Allowed) == 0 {
+		return nil
+	}
+	patterns := make([]*regexp.Regexp, len(config.Allowed))
+	for i, allowed := range config.Allowed {
+		patterns[i] = sourcePatternRegexp(allowed)
+	}
+
+	calls, diags := runner.GetModuleCalls()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for _, call := range calls {
+		if err := r.checkModule(runner, call, patterns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformModuleAllowedSourcesRule) checkModule(runner tflint.Runner, module *terraform.ModuleCall, patterns []*regexp.Regexp) error {
+	if !module.SourceKnown {
+		return nil
+	}
+
+	if matchSourcePatterns(patterns, module.Source) {
+		return nil
+	}
+
+	// Normalization may need network access, so it is only performed when the source as written does not match.
+	// Sources that cannot be normalized are reported as written.
+	source, err := normalizeModuleSource(module.Source, filepath.Dir(module.DefRange.Filename))
+	if err == nil && matchSourcePatterns(patterns, source) {
+		return nil
+	}
+
+	message := fmt.Sprintf("Module source %q is not allowed", module.Source)
+	if err == nil && source != module.Source {
+		message = fmt.Sprintf("Module source %q (%s) is not allowed", module.Source, source)
+	}
+	return runner.EmitIssue(r, message, module.SourceAttr.Expr.Range())
+}
+
+// normalizeModuleSource returns the source address in the form that allowed patterns are matched against.
+// Local paths are returned as is, registry addresses include the registry host,
+// and other addresses are resolved by the go-getter detectors.
+func normalizeModuleSource(source string, pwd string) (string, error) {
+	// @see https://github.com/hashicorp/terraform/blob/v1.9.0/internal/addrs/module_source.go#L80-L88
+	for _, prefix := range []string{"./", "../", ".\\", "..\\"} {
+		if strings.HasPrefix(source, prefix) {
+			return source, nil
+		}
+	}
+
+	if addr, err := tfaddr.ParseModuleSource(source); err == nil {
+		return addr.String(), nil
+	}
+
+	return getter.Detect(source, pwd, moduleSourceDetectors)
+}
+
+// matchSourcePatterns returns whether the source matches any of the patterns
+func matchSourcePatterns(patterns []*regexp.Regexp, source string) bool {
+	for _, pattern := range patterns {
+		if pattern.MatchString(source) {
+			return true
+		}
+	}
+	return false
+}
+
+// sourcePatternRegexp converts the pattern into a regular expression.
+// Unlike path.Match, "*" matches any sequence of characters including "/".
+func sourcePatternRegexp(pattern string) *regexp.Regexp {
+	parts := strings.Split(pattern, "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	return regexp.MustCompile("^" + strings.Join(parts, ".*") + "$")
+}
@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformModuleAllowedSourcesRule(t *testing.T) {
+	config := `
+rule "terraform_module_allowed_sources" {
+  enabled = true
+  allowed = [
+    "app.terraform.io/ourorg/*",
+    "git::ssh://git.internal/*",
+    "git::https://github.com/ourorg/*",
+    "./*",
+  ]
+}`
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no config",
+			Content: `
+module "consul" {
+  source = "hashicorp/consul/aws"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "allowed sources",
+			Config: config,
+			Content: `
+module "vpc" {
+  source  = "app.terraform.io/ourorg/vpc/aws"
+  version = "1.0.0"
+}
+
+module "network" {
+  source = "git::ssh://git.internal/platform/network.git?ref=v1.2.0"
+}
+
+module "dns" {
+  source = "github.com/ourorg/dns?ref=v1.0.0"
+}
+
+module "local" {
+  source = "./modules/local"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "disallowed sources",
+			Config: config,
+			Content: `
+module "consul" {
+  source = "hashicorp/consul/aws"
+}
+
+module "vpc" {
+  source = "app.terraform.io/otherorg/vpc/aws"
+}
+
+module "dns" {
+  source = "github.com/someone/dns"
+}
+
+module "parent" {
+  source = "../parent"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformModuleAllowedSourcesRule(),
+					Message: `Module source "hashicorp/consul/aws" (registry.terraform.io/hashicorp/consul/aws) is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 34},
+					},
+				},
+				{
+					Rule:    NewTerraformModuleAllowedSourcesRule(),
+					Message: `Module source "app.terraform.io/otherorg/vpc/aws" is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 12},
+						End:      hcl.Pos{Line: 7, Column: 47},
+					},
+				},
+				{
+					Rule:    NewTerraformModuleAllowedSourcesRule(),
+					Message: `Module source "github.com/someone/dns" (git::https://github.com/someone/dns.git) is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 12},
+						End:      hcl.Pos{Line: 11, Column: 36},
+					},
+				},
+				{
+					Rule:    NewTerraformModuleAllowedSourcesRule(),
+					Message: `Module source "../parent" is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 12},
+						End:      hcl.Pos{Line: 15, Column: 23},
+					},
+				},
+			},
+		},
+		{
+			Name: "sources matched as written",
+			Config: `
+rule "terraform_module_allowed_sources" {
+  enabled = true
+  allowed = ["bitbucket.org/ourorg/*"]
+}`,
+			Content: `
+module "network" {
+  source = "bitbucket.org/ourorg/network"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "sources that cannot be normalized",
+			Config: config,
+			Content: `
+module "dns" {
+  source = "github.com/someone"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformModuleAllowedSourcesRule(),
+					Message: `Module source "github.com/someone" is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 12},
+						End:      hcl.Pos{Line: 3, Column: 32},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformModuleAllowedSourcesRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
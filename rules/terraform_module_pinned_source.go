@@ -13,6 +13,17 @@ import (
 	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
 )
 
+// moduleSourceDetectors are the go-getter detectors that Terraform uses to resolve module sources
+// https://github.com/hashicorp/terraform/blob/51b0aee36cc2145f45f5b04051a01eb6eb7be8bf/internal/getmodules/getter.go#L30-L52
+var moduleSourceDetectors = []getter.Detector{
+	new(getter.GitHubDetector),
+	new(getter.GitDetector),
+	new(getter.BitBucketDetector),
+	new(getter.GCSDetector),
+	new(getter.S3Detector),
+	new(getter.FileDetector),
+}
+
 // TerraformModulePinnedSourceRule checks unpinned or default version module source
 type TerraformModulePinnedSourceRule struct {
 	tflint.DefaultRule
@@ -91,15 +102,7 @@ func (r *TerraformModulePinnedSourceRule) checkModule(runner tflint.Runner, modu
 		}
 	}
 
-	source, err := getter.Detect(module.Source, filepath.Dir(module.DefRange.Filename), []getter.Detector{
-		// https://github.com/hashicorp/terraform/blob/51b0aee36cc2145f45f5b04051a01eb6eb7be8bf/internal/getmodules/getter.go#L30-L52
-		new(getter.GitHubDetector),
-		new(getter.GitDetector),
-		new(getter.BitBucketDetector),
-		new(getter.GCSDetector),
-		new(getter.S3Detector),
-		new(getter.FileDetector),
-	})
+	source, err := getter.Detect(module.Source, filepath.Dir(module.DefRange.Filename), moduleSourceDetectors)
 	if err != nil {
 		return err
 	}
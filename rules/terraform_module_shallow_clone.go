@@ -74,15 +74,7 @@ func (r *TerraformModuleShallowCloneRule) checkModule(runner tflint.Runner, modu
 	}
 
 	filename := module.DefRange.Filename
-	source, err := getter.Detect(module.Source, filepath.Dir(filename), []getter.Detector{
-		// https://github.com/hashicorp/terraform/blob/51b0aee36cc2145f45f5b04051a01eb6eb7be8bf/internal/getmodules/getter.go#L30-L52
-		new(getter.GitHubDetector),
-		new(getter.GitDetector),
-		new(getter.BitBucketDetector),
-		new(getter.GCSDetector),
-		new(getter.S3Detector),
-		new(getter.FileDetector),
-	})
+	source, err := getter.Detect(module.Source, filepath.Dir(filename), moduleSourceDetectors)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,197 @@
+package rules
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformProviderConstraintsRule checks whether provider sources are allowed and version constraints follow the configured style
+type TerraformProviderConstraintsRule struct {
+	tflint.DefaultRule
+}
+
+type terraformProviderConstraintsRuleConfig struct {
+	AllowedSources []string `hclext:"allowed_sources,optional"`
+	VersionStyle   string   `hclext:"version_style,optional"`
+}
+
+// NewTerraformProviderConstraintsRule returns a new rule
+func NewTerraformProviderConstraintsRule() *TerraformProviderConstraintsRule {
+	return &TerraformProviderConstraintsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformProviderConstraintsRule) Name() string {
+	return "terraform_provider_constraints"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformProviderConstraintsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformProviderConstraintsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformProviderConstraintsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks the source and version of each entry in required_providers
+func (r *TerraformProviderConstraintsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformProviderConstraintsRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	switch config.VersionStyle {
+	case "", "pessimistic", "range", "exact":
+	default:
+		return fmt.Errorf("`%s` is invalid version style", config.VersionStyle)
+	}
+	patterns := make([]*regexp.Regexp, len(config.AllowedSources))
+	for i, allowed := range config.AllowedSources {
+		patterns[i] = sourcePatternRegexp(allowed)
+	}
+
+	requiredProviders, diags := runner.GetRequiredProviders()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	names := make([]string, 0, len(requiredProviders))
+	for name := range requiredProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if err := r.checkProvider(runner, name, requiredProviders[name], patterns, config.VersionStyle); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformProviderConstraintsRule) checkProvider(runner tflint.Runner, name string, attr *hcl.Attribute, patterns []*regexp.Regexp, style string) error {
+	provider := terraform.DecodeRequiredProvider(attr)
+
+	if len(patterns) > 0 {
+		sourceRange := attr.Expr.Range()
+		if provider.SourceExpr != nil {
+			sourceRange = provider.SourceExpr.Range()
+		}
+		if err := r.checkSource(runner, name, provider.Source, sourceRange, patterns); err != nil {
+			return err
+		}
+	}
+
+	versionExpr := provider.VersionExpr
+	if versionExpr == nil {
+		return nil
+	}
+	val, diags := versionExpr.Value(nil)
+	if diags.HasErrors() || val.Type() != cty.String || !val.IsKnown() || val.IsNull() {
+		return nil
+	}
+	constraints, err := version.NewConstraint(val.AsString())
+	if err != nil {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("Version constraint %q for provider %q is invalid: %s", val.AsString(), name, err),
+			versionExpr.Range(),
+		)
+	}
+
+	if message := versionStyleViolation(constraints, style); message != "" {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("Version constraint %q for provider %q %s", val.AsString(), name, message),
+			versionExpr.Range(),
+		)
+	}
+
+	if terraform.AllowsMajorUpgrade(constraints) {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("Version constraint %q for provider %q allows major version upgrades", val.AsString(), name),
+			versionExpr.Range(),
+		)
+	}
+
+	return nil
+}
+
+func (r *TerraformProviderConstraintsRule) checkSource(runner tflint.Runner, name string, source string, rng hcl.Range, patterns []*regexp.Regexp) error {
+	provider, err := tfaddr.ParseProviderSource(source)
+	if err != nil {
+		return runner.EmitIssue(
+			r,
+			fmt.Sprintf("Source %q for provider %q is invalid: %s", source, name, err),
+			rng,
+		)
+	}
+	if provider.IsBuiltIn() {
+		return nil
+	}
+
+	for _, pattern := range patterns {
+		if pattern.MatchString(provider.ForDisplay()) || pattern.MatchString(provider.String()) {
+			return nil
+		}
+	}
+
+	return runner.EmitIssue(
+		r,
+		fmt.Sprintf("Source %q for provider %q is not allowed", provider.ForDisplay(), name),
+		rng,
+	)
+}
+
+// versionStyleViolation returns the reason why the constraints don't follow the style,
+// or an empty string if they follow it
+func versionStyleViolation(constraints version.Constraints, style string) string {
+	operators := map[string]bool{}
+	for _, c := range constraints {
+		operators[terraform.ConstraintOperator(c)] = true
+	}
+
+	switch style {
+	case "pessimistic":
+		if !operators["~>"] {
+			return `should use the pessimistic constraint operator "~>"`
+		}
+	case "range":
+		if !(operators[">="] || operators[">"]) || !(operators["<"] || operators["<="]) {
+			return "should specify both a lower and an upper bound"
+		}
+	case "exact":
+		if len(constraints) != 1 || !operators["="] {
+			return "should specify an exact version"
+		}
+	}
+	return ""
+}
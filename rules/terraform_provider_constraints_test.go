@@ -0,0 +1,234 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformProviderConstraintsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "no config",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source                = "hashicorp/aws"
+      version               = "~> 5.0"
+      configuration_aliases = [aws.west]
+    }
+    random = {
+      source  = "someone/random"
+      version = ">= 3.1, < 4.0"
+    }
+    terraform = {
+      source = "terraform.io/builtin/terraform"
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "major upgrades",
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = ">= 5.0"
+    }
+    google = "~> 5"
+    azurerm = "< 5.0"
+    random = "<= 3.9"
+    null = ">= 3.0, <= 4.0"
+    tls = "~> 4.0"
+    local = ">= 2.0, < 4.0"
+    time = ">= 0.9, < 1.0"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint ">= 5.0" for provider "aws" allows major version upgrades`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 25},
+					},
+				},
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint "~> 5" for provider "google" allows major version upgrades`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 14},
+						End:      hcl.Pos{Line: 8, Column: 20},
+					},
+				},
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint ">= 2.0, < 4.0" for provider "local" allows major version upgrades`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 13},
+						End:      hcl.Pos{Line: 13, Column: 28},
+					},
+				},
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint ">= 3.0, <= 4.0" for provider "null" allows major version upgrades`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 11, Column: 12},
+						End:      hcl.Pos{Line: 11, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "allowed sources",
+			Config: `
+rule "terraform_provider_constraints" {
+  enabled         = true
+  allowed_sources = ["hashicorp/*", "registry.example.com/*"]
+}`,
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+    google = {}
+    internal = {
+      source = "registry.example.com/platform/internal"
+    }
+    random = {
+      source = "someone/random"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Source "someone/random" for provider "random" is not allowed`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 12, Column: 16},
+						End:      hcl.Pos{Line: 12, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "pessimistic style",
+			Config: `
+rule "terraform_provider_constraints" {
+  enabled       = true
+  version_style = "pessimistic"
+}`,
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      version = "~> 5.0"
+    }
+    google = {
+      version = ">= 5.0, < 6.0"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint ">= 5.0, < 6.0" for provider "google" should use the pessimistic constraint operator "~>"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 17},
+						End:      hcl.Pos{Line: 8, Column: 32},
+					},
+				},
+			},
+		},
+		{
+			Name: "range style",
+			Config: `
+rule "terraform_provider_constraints" {
+  enabled       = true
+  version_style = "range"
+}`,
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      version = "~> 5.0"
+    }
+    google = {
+      version = ">= 5.0, < 6.0"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint "~> 5.0" for provider "aws" should specify both a lower and an upper bound`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 17},
+						End:      hcl.Pos{Line: 5, Column: 25},
+					},
+				},
+			},
+		},
+		{
+			Name: "exact style",
+			Config: `
+rule "terraform_provider_constraints" {
+  enabled       = true
+  version_style = "exact"
+}`,
+			Content: `
+terraform {
+  required_providers {
+    aws = {
+      version = "5.31.0"
+    }
+    google = {
+      version = "~> 5.0"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformProviderConstraintsRule(),
+					Message: `Version constraint "~> 5.0" for provider "google" should specify an exact version`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 17},
+						End:      hcl.Pos{Line: 8, Column: 25},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformProviderConstraintsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := testRunner(t, map[string]string{"main.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
@@ -0,0 +1,233 @@
+package rules
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/ext/typeexpr"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	tfsdk "github.com/terraform-linters/tflint-plugin-sdk/terraform"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// legacyQuotedTypes are type constraints that had a different meaning when quoted
+var legacyQuotedTypes = map[string]string{
+	"list": "list(string)",
+	"map":  "map(string)",
+}
+
+// TerraformQuotedReferencesRule checks whether meta-arguments use 0.11-style quoted references
+type TerraformQuotedReferencesRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformQuotedReferencesRule returns a new rule
+func NewTerraformQuotedReferencesRule() *TerraformQuotedReferencesRule {
+	return &TerraformQuotedReferencesRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformQuotedReferencesRule) Name() string {
+	return "terraform_quoted_references"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformQuotedReferencesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformQuotedReferencesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformQuotedReferencesRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether depends_on, provider, providers, ignore_changes, and variable types are quoted
+func (r *TerraformQuotedReferencesRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	resourceSchema := &hclext.BodySchema{
+		Attributes: []hclext.AttributeSchema{
+			{Name: "depends_on"},
+			{Name: "provider"},
+		},
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "lifecycle",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "ignore_changes"}},
+				},
+			},
+		},
+	}
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "resource",
+				LabelNames: []string{"type", "name"},
+				Body:       resourceSchema,
+			},
+			{
+				Type:       "data",
+				LabelNames: []string{"type", "name"},
+				Body:       resourceSchema,
+			},
+			{
+				Type:       "ephemeral",
+				LabelNames: []string{"type", "name"},
+				Body:       resourceSchema,
+			},
+			{
+				Type:       "module",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "depends_on"},
+						{Name: "providers"},
+					},
+				},
+			},
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "depends_on"}},
+				},
+			},
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "type"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	for _, block := range body.Blocks {
+		if tfsdk.IsJSONFilename(block.DefRange.Filename) {
+			// References are always quoted in JSON syntax.
+			continue
+		}
+
+		if attr, exists := block.Body.Attributes["depends_on"]; exists {
+			exprs, diags := hcl.ExprList(attr.Expr)
+			if !diags.HasErrors() {
+				for _, expr := range exprs {
+					if err := r.checkQuotedTraversal(runner, "depends_on", expr); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if attr, exists := block.Body.Attributes["provider"]; exists {
+			if err := r.checkQuotedTraversal(runner, "provider", attr.Expr); err != nil {
+				return err
+			}
+		}
+		if attr, exists := block.Body.Attributes["providers"]; exists {
+			pairs, diags := hcl.ExprMap(attr.Expr)
+			if !diags.HasErrors() {
+				for _, pair := range pairs {
+					if err := r.checkQuotedTraversal(runner, "providers", pair.Value); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		for _, lifecycle := range block.Body.Blocks {
+			attr, exists := lifecycle.Body.Attributes["ignore_changes"]
+			if !exists {
+				continue
+			}
+			exprs, diags := hcl.ExprList(attr.Expr)
+			if diags.HasErrors() {
+				continue
+			}
+			for _, expr := range exprs {
+				if err := r.checkQuotedTraversal(runner, "ignore_changes", expr); err != nil {
+					return err
+				}
+			}
+		}
+		if attr, exists := block.Body.Attributes["type"]; exists {
+			if err := r.checkQuotedType(runner, attr.Expr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformQuotedReferencesRule) checkQuotedTraversal(runner tflint.Runner, name string, expr hcl.Expression) error {
+	traversal, ok := quotedString(expr)
+	if !ok {
+		return nil
+	}
+	if _, diags := terraform.ShimTraversalInString(expr); diags.HasErrors() {
+		// The string is not a reference, which Terraform reports as an error.
+		return nil
+	}
+
+	return runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("Quoted references in %q are deprecated. Use %s instead", name, traversal),
+		expr.Range(),
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(expr.Range(), traversal)
+		},
+	)
+}
+
+func (r *TerraformQuotedReferencesRule) checkQuotedType(runner tflint.Runner, expr hcl.Expression) error {
+	typeName, ok := quotedString(expr)
+	if !ok {
+		return nil
+	}
+	if legacy, exists := legacyQuotedTypes[typeName]; exists {
+		typeName = legacy
+	}
+	// Only valid type constraints are fixed. Other strings are invalid in any version, which Terraform reports as an error.
+	typeExpr, diags := hclsyntax.ParseExpression([]byte(typeName), expr.Range().Filename, expr.Range().Start)
+	if diags.HasErrors() {
+		return nil
+	}
+	if _, _, diags := typeexpr.TypeConstraintWithDefaults(typeExpr); diags.HasErrors() {
+		return nil
+	}
+
+	return runner.EmitIssueWithFix(
+		r,
+		fmt.Sprintf("Quoted type constraints are deprecated. Use %s instead", typeName),
+		expr.Range(),
+		func(f tflint.Fixer) error {
+			return f.ReplaceText(expr.Range(), typeName)
+		},
+	)
+}
+
+// quotedString returns the value of a quoted string without interpolations
+func quotedString(expr hcl.Expression) (string, bool) {
+	if _, ok := expr.(*hclsyntax.TemplateExpr); !ok {
+		return "", false
+	}
+	return literalString(expr)
+}
@@ -0,0 +1,236 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformQuotedReferencesRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "quoted references",
+			Content: `
+resource "aws_instance" "web" {
+  provider   = "aws.west"
+  depends_on = ["aws_s3_bucket.logs", module.network]
+
+  lifecycle {
+    ignore_changes = ["tags", ami]
+  }
+}
+
+module "network" {
+  source    = "./network"
+  providers = {
+    aws = "aws.west"
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: `Quoted references in "depends_on" are deprecated. Use aws_s3_bucket.logs instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 37},
+					},
+				},
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: `Quoted references in "provider" are deprecated. Use aws.west instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 16},
+						End:      hcl.Pos{Line: 3, Column: 26},
+					},
+				},
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: `Quoted references in "ignore_changes" are deprecated. Use tags instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 23},
+						End:      hcl.Pos{Line: 7, Column: 29},
+					},
+				},
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: `Quoted references in "providers" are deprecated. Use aws.west instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 14, Column: 11},
+						End:      hcl.Pos{Line: 14, Column: 21},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  provider   = aws.west
+  depends_on = [aws_s3_bucket.logs, module.network]
+
+  lifecycle {
+    ignore_changes = [tags, ami]
+  }
+}
+
+module "network" {
+  source = "./network"
+  providers = {
+    aws = aws.west
+  }
+}`,
+		},
+		{
+			Name: "quoted type constraints",
+			Content: `
+variable "name" {
+  type = "string"
+}
+
+variable "zones" {
+  type = "list"
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+variable "settings" {
+  type = "object({ name = string, port = optional(number) })"
+}
+
+variable "foo" {
+  type = "foo"
+}
+
+variable "bar" {
+  type = "list(foo)"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: "Quoted type constraints are deprecated. Use string instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 10},
+						End:      hcl.Pos{Line: 3, Column: 18},
+					},
+				},
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: "Quoted type constraints are deprecated. Use list(string) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 10},
+						End:      hcl.Pos{Line: 7, Column: 16},
+					},
+				},
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: "Quoted type constraints are deprecated. Use object({ name = string, port = optional(number) }) instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 15, Column: 10},
+						End:      hcl.Pos{Line: 15, Column: 62},
+					},
+				},
+			},
+			Fixed: `
+variable "name" {
+  type = string
+}
+
+variable "zones" {
+  type = list(string)
+}
+
+variable "tags" {
+  type = map(string)
+}
+
+variable "settings" {
+  type = object({ name = string, port = optional(number) })
+}
+
+variable "foo" {
+  type = "foo"
+}
+
+variable "bar" {
+  type = "list(foo)"
+}`,
+		},
+		{
+			Name: "output depends_on",
+			Content: `
+output "id" {
+  value      = aws_instance.web.id
+  depends_on = ["aws_instance.web"]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformQuotedReferencesRule(),
+					Message: `Quoted references in "depends_on" are deprecated. Use aws_instance.web instead`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 35},
+					},
+				},
+			},
+			Fixed: `
+output "id" {
+  value      = aws_instance.web.id
+  depends_on = [aws_instance.web]
+}`,
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "resource": {
+    "aws_instance": {
+      "web": {
+        "provider": "aws.west",
+        "depends_on": ["aws_s3_bucket.logs"]
+      }
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformQuotedReferencesRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
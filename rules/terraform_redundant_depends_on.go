@@ -0,0 +1,241 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/lang"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+// TerraformRedundantDependsOnRule checks whether depends_on entries are already implied by references
+type TerraformRedundantDependsOnRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformRedundantDependsOnRule returns a new rule
+func NewTerraformRedundantDependsOnRule() *TerraformRedundantDependsOnRule {
+	return &TerraformRedundantDependsOnRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformRedundantDependsOnRule) Name() string {
+	return "terraform_redundant_depends_on"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformRedundantDependsOnRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformRedundantDependsOnRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformRedundantDependsOnRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether depends_on entries name resources that the block already references,
+// and whether depends_on is set on data sources and module calls
+func (r *TerraformRedundantDependsOnRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		body, ok := file.Body.(*hclsyntax.Body)
+		if !ok {
+			// Nested blocks cannot be distinguished from arguments in JSON syntax.
+			continue
+		}
+
+		for _, block := range body.Blocks {
+			switch block.Type {
+			case "resource", "data", "ephemeral", "module", "output":
+			default:
+				continue
+			}
+
+			attr, exists := block.Body.Attributes["depends_on"]
+			if !exists {
+				continue
+			}
+
+			switch block.Type {
+			case "data":
+				if err := runner.EmitIssue(
+					r,
+					"depends_on in a data source defers reading it until apply whenever a dependency has pending changes. Reference the attributes it needs instead",
+					attr.Range(),
+				); err != nil {
+					return err
+				}
+			case "module":
+				if err := runner.EmitIssue(
+					r,
+					"depends_on in a module call defers reading all data sources in the module until apply whenever a dependency has pending changes. Pass the values it needs as inputs instead",
+					attr.Range(),
+				); err != nil {
+					return err
+				}
+			}
+
+			if err := r.checkEntries(runner, block.Body, attr); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformRedundantDependsOnRule) checkEntries(runner tflint.Runner, body *hclsyntax.Body, attr *hclsyntax.Attribute) error {
+	tuple, ok := attr.Expr.(*hclsyntax.TupleConsExpr)
+	if !ok {
+		return nil
+	}
+
+	implicit := map[string]bool{}
+	collectImplicitDependencies(body, implicit)
+
+	redundant := make([]bool, len(tuple.Exprs))
+	for i, expr := range tuple.Exprs {
+		redundant[i] = implicit[dependencyAddress(expr)]
+	}
+	// Redundant entries at the end of the list are removed with the preceding separator.
+	// If all entries are redundant, every fix removes the whole attribute instead.
+	trailing := len(tuple.Exprs)
+	for trailing > 0 && redundant[trailing-1] {
+		trailing--
+	}
+
+	for i, expr := range tuple.Exprs {
+		if !redundant[i] {
+			continue
+		}
+
+		if err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("%q in depends_on is redundant because it is already referenced by this block", dependencyAddress(expr)),
+			expr.Range(),
+			func(f tflint.Fixer) error {
+				if trailing == 0 {
+					return f.RemoveAttribute(attr.AsHCLAttribute())
+				}
+				return removeTupleElement(f, tuple, i, i >= trailing)
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// removeTupleElement removes only the i-th element of the tuple so that fixes for other elements
+// can be applied or skipped independently. If every element is on its own line, the whole line is removed.
+// Otherwise, the element is removed with the following separator, or with the preceding separator if preceding is true.
+// The last element without the preceding separator is removed with its trailing comma if any.
+// Callers must ensure that elements removed together do not claim the same separator.
+func removeTupleElement(f tflint.Fixer, tuple *hclsyntax.TupleConsExpr, i int, preceding bool) error {
+	filename := tuple.SrcRange.Filename
+	// The gaps between the brackets and the elements. gaps[i] precedes the i-th element.
+	bounds := []hcl.Pos{tuple.OpenRange.End}
+	for _, expr := range tuple.Exprs {
+		bounds = append(bounds, expr.Range().Start, expr.Range().End)
+	}
+	closeStart := tuple.SrcRange.End
+	closeStart.Byte--
+	closeStart.Column--
+	bounds = append(bounds, closeStart)
+
+	gaps := make([][]byte, len(tuple.Exprs)+1)
+	multiline := true
+	for j := range gaps {
+		gaps[j] = f.TextAt(hcl.Range{Filename: filename, Start: bounds[2*j], End: bounds[2*j+1]}).Bytes
+		if !bytes.ContainsRune(gaps[j], '\n') {
+			multiline = false
+		}
+	}
+
+	elem := tuple.Exprs[i].Range()
+	start, end := elem.Start, elem.End
+	before, after := gaps[i], gaps[i+1]
+	switch {
+	case multiline:
+		start = bounds[2*i]
+		start.Byte += bytes.LastIndexByte(before, '\n') + 1
+		end.Byte += bytes.IndexByte(after, '\n') + 1
+	case preceding && i > 0:
+		start = tuple.Exprs[i-1].Range().End
+	case i < len(tuple.Exprs)-1:
+		end = tuple.Exprs[i+1].Range().Start
+	default:
+		if comma := bytes.IndexByte(after, ','); comma >= 0 {
+			end.Byte += comma + 1
+		}
+	}
+
+	return f.ReplaceText(hcl.Range{Filename: filename, Start: start, End: end}, "")
+}
+
+// collectImplicitDependencies adds the addresses of resources referenced in the body,
+// except in depends_on, to the given set
+func collectImplicitDependencies(body *hclsyntax.Body, deps map[string]bool) {
+	for name, attr := range body.Attributes {
+		if name == "depends_on" {
+			continue
+		}
+		for _, ref := range lang.ReferencesInExpr(attr.Expr) {
+			// References to module outputs depend only on the output,
+			// so they don't imply depends_on for the whole module call.
+			switch subject := ref.Subject.(type) {
+			case addrs.Resource:
+				deps[subject.String()] = true
+			case addrs.ResourceInstance:
+				deps[subject.Resource.String()] = true
+			}
+		}
+	}
+
+	for _, block := range body.Blocks {
+		collectImplicitDependencies(block.Body, deps)
+	}
+}
+
+// dependencyAddress returns the resource address named by a depends_on entry,
+// or an empty string if the entry is not a resource
+func dependencyAddress(expr hcl.Expression) string {
+	traversal, diags := hcl.AbsTraversalForExpr(expr)
+	if diags.HasErrors() {
+		return ""
+	}
+	ref, diags := addrs.ParseRef(traversal)
+	if diags.HasErrors() {
+		return ""
+	}
+
+	switch subject := ref.Subject.(type) {
+	case addrs.Resource:
+		return subject.String()
+	case addrs.ResourceInstance:
+		return subject.Resource.String()
+	}
+	return ""
+}
@@ -0,0 +1,373 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+)
+
+func Test_TerraformRedundantDependsOnRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "no redundant entries",
+			Content: `
+resource "aws_instance" "web" {
+  subnet_id  = module.network.subnet_id
+  depends_on = [aws_iam_role_policy.web, module.network]
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "some entries are redundant",
+			Content: `
+resource "aws_instance" "web" {
+  subnet_id  = aws_subnet.main[0].id
+  depends_on = [aws_subnet.main, aws_iam_role_policy.web, aws_security_group.web]
+
+  dynamic "network_interface" {
+    for_each = aws_security_group.web.ids
+    content {}
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_subnet.main" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 32},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_security_group.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 59},
+						End:      hcl.Pos{Line: 4, Column: 81},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  subnet_id  = aws_subnet.main[0].id
+  depends_on = [aws_iam_role_policy.web]
+
+  dynamic "network_interface" {
+    for_each = aws_security_group.web.ids
+    content {}
+  }
+}`,
+		},
+		{
+			Name: "all entries are redundant",
+			Content: `
+output "ip" {
+  value      = aws_instance.web.private_ip
+  depends_on = [aws_instance.web]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_instance.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 33},
+					},
+				},
+			},
+			Fixed: `
+output "ip" {
+  value = aws_instance.web.private_ip
+}`,
+		},
+		{
+			Name: "multiline list",
+			Content: `
+resource "aws_instance" "web" {
+  subnet_id = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+
+  depends_on = [
+    aws_subnet.main,
+    aws_iam_role_policy.web,
+    aws_security_group.web,
+  ]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_subnet.main" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 5},
+						End:      hcl.Pos{Line: 7, Column: 20},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_security_group.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 9, Column: 5},
+						End:      hcl.Pos{Line: 9, Column: 27},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+
+  depends_on = [
+    aws_iam_role_policy.web,
+  ]
+}`,
+		},
+		{
+			Name: "trailing entries are redundant",
+			Content: `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_iam_role_policy.web, aws_subnet.main, aws_security_group.web]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_subnet.main" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 54},
+						End:      hcl.Pos{Line: 5, Column: 69},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_security_group.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 5, Column: 71},
+						End:      hcl.Pos{Line: 5, Column: 93},
+					},
+				},
+			},
+			Fixed: `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_iam_role_policy.web]
+}`,
+		},
+		{
+			Name: "all of multiple entries are redundant",
+			Content: `
+output "ip" {
+  value      = [aws_instance.web.private_ip, aws_eip.web.public_ip]
+  depends_on = [aws_instance.web, aws_eip.web]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_instance.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 17},
+						End:      hcl.Pos{Line: 4, Column: 33},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"aws_eip.web" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 35},
+						End:      hcl.Pos{Line: 4, Column: 46},
+					},
+				},
+			},
+			Fixed: `
+output "ip" {
+  value = [aws_instance.web.private_ip, aws_eip.web.public_ip]
+}`,
+		},
+		{
+			Name: "data sources and module calls",
+			Content: `
+data "aws_ami" "web" {
+  owners     = ["self"]
+  depends_on = [aws_iam_role.web]
+}
+
+module "app" {
+  source     = "./app"
+  role       = data.aws_iam_role.app.name
+  depends_on = [data.aws_iam_role.app]
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: "depends_on in a data source defers reading it until apply whenever a dependency has pending changes. Reference the attributes it needs instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 4, Column: 3},
+						End:      hcl.Pos{Line: 4, Column: 34},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: "depends_on in a module call defers reading all data sources in the module until apply whenever a dependency has pending changes. Pass the values it needs as inputs instead",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 3},
+						End:      hcl.Pos{Line: 10, Column: 39},
+					},
+				},
+				{
+					Rule:    NewTerraformRedundantDependsOnRule(),
+					Message: `"data.aws_iam_role.app" in depends_on is redundant because it is already referenced by this block`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 10, Column: 17},
+						End:      hcl.Pos{Line: 10, Column: 38},
+					},
+				},
+			},
+			Fixed: `
+data "aws_ami" "web" {
+  owners     = ["self"]
+  depends_on = [aws_iam_role.web]
+}
+
+module "app" {
+  source = "./app"
+  role   = data.aws_iam_role.app.name
+}`,
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "output": {
+    "ip": {
+      "value": "${aws_instance.web.private_ip}",
+      "depends_on": ["aws_instance.web"]
+    }
+  }
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformRedundantDependsOnRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
+
+// ignoringRunner is a runner that discards fixes for issues with the given messages, as TFLint does for ignored issues.
+// Like TFLint, it runs the fixes before discarding them.
+type ignoringRunner struct {
+	*helper.Runner
+	discarded *helper.Runner
+	ignored   map[string]bool
+}
+
+func newIgnoringRunner(t *testing.T, files map[string]string, ignored ...string) *ignoringRunner {
+	runner := &ignoringRunner{
+		Runner:    helper.TestRunner(t, files),
+		discarded: helper.TestRunner(t, files),
+		ignored:   map[string]bool{},
+	}
+	for _, message := range ignored {
+		runner.ignored[message] = true
+	}
+	return runner
+}
+
+func (r *ignoringRunner) EmitIssueWithFix(rule tflint.Rule, message string, location hcl.Range, fixFunc func(f tflint.Fixer) error) error {
+	if r.ignored[message] {
+		return r.discarded.EmitIssueWithFix(rule, message, location, fixFunc)
+	}
+	return r.Runner.EmitIssueWithFix(rule, message, location, fixFunc)
+}
+
+func Test_TerraformRedundantDependsOnRule_ignored(t *testing.T) {
+	content := `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_subnet.main, aws_iam_role_policy.web, aws_security_group.web]
+}`
+
+	cases := []struct {
+		Name    string
+		Ignored string
+		Fixed   string
+	}{
+		{
+			Name:    "first entry ignored",
+			Ignored: `"aws_subnet.main" in depends_on is redundant because it is already referenced by this block`,
+			Fixed: `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_subnet.main, aws_iam_role_policy.web]
+}`,
+		},
+		{
+			Name:    "last entry ignored",
+			Ignored: `"aws_security_group.web" in depends_on is redundant because it is already referenced by this block`,
+			Fixed: `
+resource "aws_instance" "web" {
+  subnet_id              = aws_subnet.main.id
+  vpc_security_group_ids = [aws_security_group.web.id]
+  depends_on             = [aws_iam_role_policy.web, aws_security_group.web]
+}`,
+		},
+	}
+
+	rule := NewTerraformRedundantDependsOnRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := newIgnoringRunner(t, map[string]string{"main.tf": content}, tc.Ignored)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertChanges(t, map[string]string{"main.tf": tc.Fixed}, runner.Changes())
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package rules
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// TerraformRequiredAttributesRule checks whether resources set the arguments required for their types
+type TerraformRequiredAttributesRule struct {
+	tflint.DefaultRule
+}
+
+type terraformRequiredAttributesRuleConfig struct {
+	Resources []terraformRequiredAttributesResourceConfig `hclext:"resource,block"`
+}
+
+// terraformRequiredAttributesResourceConfig is the requirement for resource types matching the glob pattern
+type terraformRequiredAttributesResourceConfig struct {
+	Type       string              `hclext:"type,label"`
+	Attributes []string            `hclext:"attributes,optional"`
+	Keys       map[string][]string `hclext:"keys,optional"`
+}
+
+// NewTerraformRequiredAttributesRule returns a new rule
+func NewTerraformRequiredAttributesRule() *TerraformRequiredAttributesRule {
+	return &TerraformRequiredAttributesRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformRequiredAttributesRule) Name() string {
+	return "terraform_required_attributes"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformRequiredAttributesRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformRequiredAttributesRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformRequiredAttributesRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether resources matching the configured type patterns set the required arguments,
+// and whether the values of the arguments include the required keys
+func (r *TerraformRequiredAttributesRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	config := terraformRequiredAttributesRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	for i, resource := range config.Resources {
+		// Arguments with required keys are also required.
+		for name := range resource.Keys {
+			if !slices.Contains(resource.Attributes, name) {
+				config.Resources[i].Attributes = append(config.Resources[i].Attributes, name)
+			}
+		}
+	}
+	if len(config.Resources) == 0 {
+		return nil
+	}
+
+	// Arguments can also be written as nested blocks, or generated by dynamic blocks.
+	schema := &hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{{Type: "dynamic", LabelNames: []string{"name"}}},
+	}
+	declared := map[string]bool{}
+	for _, resource := range config.Resources {
+		for _, name := range resource.Attributes {
+			if declared[name] {
+				continue
+			}
+			declared[name] = true
+			schema.Attributes = append(schema.Attributes, hclext.AttributeSchema{Name: name})
+			schema.Blocks = append(schema.Blocks, hclext.BlockSchema{Type: name})
+		}
+	}
+
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{Type: "resource", LabelNames: []string{"type", "name"}, Body: schema},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	locals, diags := runner.GetLocals()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	for _, block := range body.Blocks {
+		for _, resource := range config.Resources {
+			if matched, err := matchTypePattern(resource.Type, block.Labels[0]); err != nil {
+				return err
+			} else if !matched {
+				continue
+			}
+
+			for _, name := range resource.Attributes {
+				attr, exists := block.Body.Attributes[name]
+				if !exists {
+					if argumentBlockExists(block.Body, name) {
+						continue
+					}
+					if err := runner.EmitIssue(
+						r,
+						fmt.Sprintf("%q is required for resources matching %q", name, resource.Type),
+						block.DefRange,
+					); err != nil {
+						return err
+					}
+					continue
+				}
+
+				requiredKeys := resource.Keys[name]
+				if len(requiredKeys) == 0 {
+					continue
+				}
+				keys, ok := runner.GetObjectKeys(attr.Expr, locals)
+				if !ok {
+					// Keys that are only known after evaluation cannot be checked.
+					continue
+				}
+				for _, key := range requiredKeys {
+					if slices.Contains(keys, key) {
+						continue
+					}
+					if err := runner.EmitIssue(
+						r,
+						fmt.Sprintf("%q must include the key %q for resources matching %q", name, key, resource.Type),
+						attr.Expr.Range(),
+					); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// argumentBlockExists returns whether the body has a nested block or dynamic block of the given type
+func argumentBlockExists(body *hclext.BodyContent, name string) bool {
+	for _, block := range body.Blocks {
+		if block.Type == name {
+			return true
+		}
+		if block.Type == "dynamic" && block.Labels[0] == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,175 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformRequiredAttributesRule(t *testing.T) {
+	config := `
+rule "terraform_required_attributes" {
+  enabled = true
+
+  resource "aws_*" {
+    keys = {
+      tags = ["Owner"]
+    }
+  }
+
+  resource "google_*" {
+    attributes = ["labels"]
+  }
+
+  resource "aws_instance" {
+    attributes = ["ebs_block_device"]
+  }
+}`
+
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		JSON     bool
+		Expected helper.Issues
+	}{
+		{
+			Name: "no config",
+			Content: `
+resource "aws_instance" "web" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "required attributes are set",
+			Config: config,
+			Content: `
+locals {
+  tags = { Owner = "platform" }
+}
+
+resource "aws_instance" "web" {
+  tags = merge(local.tags, { Name = "web" })
+
+  dynamic "ebs_block_device" {
+    for_each = var.volumes
+    content {}
+  }
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = var.tags
+}
+
+resource "google_compute_instance" "web" {
+  labels = {}
+}
+
+resource "azurerm_resource_group" "main" {}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:   "missing attributes",
+			Config: config,
+			Content: `
+resource "aws_instance" "web" {
+  ebs_block_device {}
+}
+
+resource "google_compute_instance" "web" {}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredAttributesRule(),
+					Message: `"tags" is required for resources matching "aws_*"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 30},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredAttributesRule(),
+					Message: `"labels" is required for resources matching "google_*"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 41},
+					},
+				},
+			},
+		},
+		{
+			Name:   "missing keys",
+			Config: config,
+			Content: `
+locals {
+  tags = { Environment = "prod" }
+}
+
+resource "aws_s3_bucket" "logs" {
+  tags = merge(local.tags, { Name = "logs" })
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredAttributesRule(),
+					Message: `"tags" must include the key "Owner" for resources matching "aws_*"`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 7, Column: 10},
+						End:      hcl.Pos{Line: 7, Column: 46},
+					},
+				},
+			},
+		},
+		{
+			Name:   "JSON",
+			JSON:   true,
+			Config: config,
+			Content: `
+{
+  "resource": {
+    "aws_s3_bucket": {
+      "logs": {
+        "tags": { "Name": "logs" }
+      }
+    },
+    "google_compute_instance": {
+      "web": {
+        "labels": {}
+      }
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredAttributesRule(),
+					Message: `"tags" must include the key "Owner" for resources matching "aws_*"`,
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 6, Column: 17},
+						End:      hcl.Pos{Line: 6, Column: 35},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformRequiredAttributesRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := testRunner(t, map[string]string{filename: tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
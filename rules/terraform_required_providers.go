@@ -2,8 +2,12 @@ package rules
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"sync"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	tfaddr "github.com/hashicorp/terraform-registry-address"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	tfsdk "github.com/terraform-linters/tflint-plugin-sdk/terraform"
@@ -23,6 +27,8 @@ type terraformRequiredProvidersRuleConfig struct {
 	Source *bool `hclext:"source,optional"`
 	// Version specifies whether the rule should assert the presence of a `version` attribute
 	Version *bool `hclext:"version,optional"`
+	// Namespaces maps provider local names to the namespaces used in fixes, instead of "hashicorp"
+	Namespaces map[string]string `hclext:"namespaces,optional"`
 }
 
 // NewTerraformRequiredProvidersRule returns new rule with default attributes
@@ -149,15 +155,42 @@ func (r *TerraformRequiredProvidersRule) Check(rr tflint.Runner) error {
 	}
 
 	requiredProviders := hclext.Attributes{}
+	requiredProvidersBlockExists := false
 	for _, terraform := range body.Blocks {
 		for _, requiredProvidersBlock := range terraform.Body.Blocks {
+			requiredProvidersBlockExists = true
 			for name, attr := range requiredProvidersBlock.Body.Attributes {
 				requiredProviders[name] = attr
 			}
 		}
 	}
 
-	for name, ref := range providerRefs {
+	names := make([]string, 0, len(providerRefs))
+	for name := range providerRefs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	missing := []string{}
+	for _, name := range names {
+		if _, exists := requiredProviders[name]; !exists && name != "terraform" {
+			missing = append(missing, name)
+		}
+	}
+	// A module can only have one required_providers block. If there is none, every fix writes the same block
+	// with entries for all missing providers at the same range, so the block is created once
+	// no matter which of the fixes are applied.
+	blockFilename := ""
+	if len(missing) > 0 {
+		blockFilename = providerRefs[missing[0]].DefRange.Filename
+	}
+	// The lock file is read at most once, and only when a fix adds entries.
+	lockedProviders := sync.OnceValues(func() (map[string]*terraform.LockedProvider, hcl.Diagnostics) {
+		return terraform.LoadLockFile(filepath.Dir(blockFilename))
+	})
+
+	for _, name := range names {
+		ref := providerRefs[name]
 		if name == "terraform" {
 			// "terraform" provider is a builtin provider
 			// @see https://github.com/hashicorp/terraform/blob/v1.2.5/internal/addrs/provider.go#L106-L112
@@ -166,10 +199,16 @@ func (r *TerraformRequiredProvidersRule) Check(rr tflint.Runner) error {
 
 		requiredProvider, exists := requiredProviders[name]
 		if !exists {
-			if err := runner.EmitIssue(
+			if err := runner.EmitIssueWithFix(
 				r,
 				fmt.Sprintf("Missing version constraint for provider %q in `required_providers`", name),
 				ref.DefRange,
+				func(f tflint.Fixer) error {
+					if requiredProvidersBlockExists {
+						return r.insertRequiredProviders(f, runner, blockFilename, []string{name}, true, config, lockedProviders)
+					}
+					return r.insertRequiredProviders(f, runner, blockFilename, missing, false, config, lockedProviders)
+				},
 			); err != nil {
 				return err
 			}
@@ -237,10 +276,10 @@ func (r *TerraformRequiredProvidersRule) Check(rr tflint.Runner) error {
 					}
 					if len(kvs) == 0 {
 						return f.ReplaceText(requiredProvider.Expr.Range(), fmt.Sprintf(`{
-							source = "hashicorp/%s"
-						}`, name))
+							source = "%s"
+						}`, r.providerSource(name, config)))
 					}
-					return f.InsertTextBefore(kvs[0].Key.StartRange(), fmt.Sprintf(`source = "hashicorp/%s"`+"\n", name))
+					return f.InsertTextBefore(kvs[0].Key.StartRange(), fmt.Sprintf(`source = "%s"`+"\n", r.providerSource(name, config)))
 				},
 			); err != nil {
 				return err
@@ -260,3 +299,92 @@ func (r *TerraformRequiredProvidersRule) Check(rr tflint.Runner) error {
 
 	return nil
 }
+
+// providerSource returns the source address used in fixes for the given provider
+func (r *TerraformRequiredProvidersRule) providerSource(name string, config *terraformRequiredProvidersRuleConfig) string {
+	if namespace, exists := config.Namespaces[name]; exists {
+		return fmt.Sprintf("%s/%s", namespace, name)
+	}
+	return "hashicorp/" + name
+}
+
+// insertRequiredProviders adds entries for the given providers to the required_providers block.
+// If the module has no required_providers block, it is created in the first terraform block,
+// or in a new terraform block at the top of the given file. The block is created by replacing
+// a fixed range, so fixes creating the same block do not conflict with each other.
+// Blocks in JSON syntax are not rewritten.
+// Version constraints are taken from the dependency lock file if it exists.
+func (r *TerraformRequiredProvidersRule) insertRequiredProviders(f tflint.Fixer, runner *terraform.Runner, filename string, names []string, blockExists bool, config *terraformRequiredProvidersRuleConfig, lockedProviders func() (map[string]*terraform.LockedProvider, hcl.Diagnostics)) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	filenames := make([]string, 0, len(files))
+	for name := range files {
+		filenames = append(filenames, name)
+	}
+	sort.Strings(filenames)
+
+	var requiredProvidersBlock, terraformBlock *hclsyntax.Block
+FileLoop:
+	for _, name := range filenames {
+		body, ok := files[name].Body.(*hclsyntax.Body)
+		if !ok {
+			continue
+		}
+		for _, block := range body.Blocks {
+			if block.Type != "terraform" {
+				continue
+			}
+			for _, nested := range block.Body.Blocks {
+				if nested.Type == "required_providers" {
+					requiredProvidersBlock = nested
+					break FileLoop
+				}
+			}
+			if terraformBlock == nil {
+				terraformBlock = block
+			}
+		}
+	}
+	if requiredProvidersBlock == nil && blockExists {
+		// The required_providers block only exists in JSON syntax.
+		return tflint.ErrFixNotSupported
+	}
+	file, exists := files[filename]
+	if requiredProvidersBlock == nil && terraformBlock == nil && (!exists || len(file.Bytes) == 0 || tfsdk.IsJSONFilename(filename)) {
+		return tflint.ErrFixNotSupported
+	}
+
+	locked, diags := lockedProviders()
+	if diags.HasErrors() {
+		return diags
+	}
+	entries := ""
+	for _, name := range names {
+		source := r.providerSource(name, config)
+		entries += fmt.Sprintf("%s = {\nsource = %q\n", name, source)
+		if addr, err := tfaddr.ParseProviderSource(source); err == nil {
+			if provider, exists := locked[addr.String()]; exists {
+				segments := provider.Version.Segments()
+				entries += fmt.Sprintf("version = \"~> %d.%d\"\n", segments[0], segments[1])
+			}
+		}
+		entries += "}\n"
+	}
+
+	switch {
+	case requiredProvidersBlock != nil:
+		return f.InsertTextBefore(requiredProvidersBlock.CloseBraceRange, entries)
+	case terraformBlock != nil:
+		return f.ReplaceText(terraformBlock.CloseBraceRange, fmt.Sprintf("required_providers {\n%s}\n}", entries))
+	}
+
+	block := fmt.Sprintf("terraform {\nrequired_providers {\n%s}\n}\n", entries)
+	if file.Bytes[0] != '\n' {
+		block += "\n"
+	}
+	// Replace the first byte rather than inserting before it, since insertions at the same position are not merged.
+	first := hcl.Range{Filename: filename, Start: hcl.InitialPos, End: hcl.Pos{Line: 1, Column: 2, Byte: 1}}
+	return f.ReplaceText(first, block+string(file.Bytes[:1]))
+}
@@ -1,20 +1,24 @@
 package rules
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/hcl/v2"
 	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
 )
 
 func Test_TerraformRequiredProvidersRule(t *testing.T) {
 	cases := []struct {
-		Name     string
-		Content  string
-		JSON     bool
-		Config   string
-		Expected helper.Issues
-		Fixed    string
+		Name          string
+		Content       string
+		JSON          bool
+		Config        string
+		RulesetConfig *terraform.Config
+		Expected      helper.Issues
+		Fixed         string
 	}{
 		{
 			Name: "no version",
@@ -38,6 +42,16 @@ provider "template" {}
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    template = {
+      source = "hashicorp/template"
+    }
+  }
+}
+
+provider "template" {}
+`,
 		},
 		{
 			Name: "implicit provider - resource",
@@ -63,6 +77,18 @@ resource "random_string" "foo" {
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "random_string" "foo" {
+  length = 16
+}
+`,
 		},
 		{
 			Name: "implicit provider - resource",
@@ -88,6 +114,18 @@ ephemeral "random_string" "foo" {
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+ephemeral "random_string" "foo" {
+  length = 16
+}
+`,
 		},
 		{
 			Name: "implicit provider - data source",
@@ -113,6 +151,18 @@ data "template_file" "foo" {
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    template = {
+      source = "hashicorp/template"
+    }
+  }
+}
+
+data "template_file" "foo" {
+  template = ""
+}
+`,
 		},
 		{
 			Name: "required_providers object",
@@ -365,6 +415,18 @@ provider "template" {
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    template = {
+      source = "hashicorp/template"
+    }
+  }
+}
+
+provider "template" {
+  alias = "b"
+}
+`,
 		},
 		{
 			Name: "version set",
@@ -506,6 +568,21 @@ resource "google_compute_instance" "foo" {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+  required_providers {
+    google = {
+      version = "~> 4.27.0"
+    }
+    google-beta = {
+      source = "hashicorp/google-beta"
+    }
+  }
+}
+
+resource "google_compute_instance" "foo" {
+  provider = google-beta
+}`,
 		},
 		{
 			Name: "resource provider ref as string",
@@ -538,6 +615,21 @@ resource "google_compute_instance" "foo" {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+  required_providers {
+    google = {
+      version = "~> 4.27.0"
+    }
+    google-beta = {
+      source = "hashicorp/google-beta"
+    }
+  }
+}
+
+resource "google_compute_instance" "foo" {
+  provider = "google-beta"
+}`,
 		},
 		{
 			Name: "JSON syntax",
@@ -594,6 +686,17 @@ output "foo" {
 					},
 				},
 			},
+			Fixed: `terraform {
+  required_providers {
+    time = {
+      source = "hashicorp/time"
+    }
+  }
+}
+
+output "foo" {
+  value = provider::time::rfc3339_parse("2023-07-25T23:43:16Z")
+}`,
 		},
 		{
 			Name: "multiple required providers",
@@ -696,6 +799,173 @@ terraform {
 }
 `,
 		},
+		{
+			Name: "multiple missing providers with namespaces",
+			Config: `
+rule "terraform_required_providers" {
+  enabled    = true
+  namespaces = {
+    datadog = "DataDog"
+  }
+}`,
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}
+
+resource "datadog_monitor" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredProvidersRule(),
+					Message: "Missing version constraint for provider \"datadog\" in `required_providers`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 1},
+						End:      hcl.Pos{Line: 6, Column: 33},
+					},
+				},
+				{
+					Rule:    NewTerraformRequiredProvidersRule(),
+					Message: "Missing version constraint for provider \"random\" in `required_providers`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 8, Column: 1},
+						End:      hcl.Pos{Line: 8, Column: 31},
+					},
+				},
+			},
+			Fixed: `
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    datadog = {
+      source = "DataDog/datadog"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "datadog_monitor" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+		},
+		{
+			Name: "local name that differs from the type prefix",
+			Content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source  = "corp/cloud"
+      version = "~> 1.0"
+    }
+  }
+}
+
+resource "corp_cloud_instance" "main" {}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "local name that differs from the type prefix missing version",
+			Content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source = "corp/cloud"
+    }
+  }
+}
+
+resource "corp_cloud_instance" "main" {}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredProvidersRule(),
+					Message: "Missing version constraint for provider \"corp_cloud\" in `required_providers`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 18},
+						End:      hcl.Pos{Line: 6, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			Name: "provider prefixes",
+			Content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source  = "corp/cloud"
+      version = "~> 1.0"
+    }
+  }
+}
+
+resource "corp_instance" "main" {}
+data "mycorp_network" "main" {}
+`,
+			RulesetConfig: &terraform.Config{
+				ProviderPrefixes: map[string]string{"corp": "corp_cloud", "mycorp": "corp_cloud"},
+			},
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "provider prefixes missing version",
+			Content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source = "corp/cloud"
+    }
+  }
+}
+
+resource "corp_instance" "main" {}
+`,
+			RulesetConfig: &terraform.Config{
+				ProviderPrefixes: map[string]string{"corp": "corp_cloud"},
+			},
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredProvidersRule(),
+					Message: "Missing version constraint for provider \"corp_cloud\" in `required_providers`",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 4, Column: 18},
+						End:      hcl.Pos{Line: 6, Column: 6},
+					},
+				},
+			},
+		},
+		{
+			Name: "missing provider in JSON syntax",
+			JSON: true,
+			Content: `{
+  "resource": {
+    "random_string": {
+      "foo": {}
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredProvidersRule(),
+					Message: "Missing version constraint for provider \"random\" in `required_providers`",
+					Range: hcl.Range{
+						Filename: "module.tf.json",
+						Start:    hcl.Pos{Line: 4, Column: 14},
+						End:      hcl.Pos{Line: 4, Column: 15},
+					},
+				},
+			},
+		},
 	}
 
 	rule := NewTerraformRequiredProvidersRule()
@@ -711,6 +981,7 @@ terraform {
 				filename:      tc.Content,
 				".tflint.hcl": tc.Config,
 			})
+			runner.Config = tc.RulesetConfig
 
 			if err := rule.Check(runner); err != nil {
 				t.Fatalf("Unexpected error occurred: %s", err)
@@ -725,3 +996,128 @@ terraform {
 		})
 	}
 }
+
+func Test_TerraformRequiredProvidersRuleLockFile(t *testing.T) {
+	dir := t.TempDir()
+	lock := `
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.2"
+}`
+	if err := os.WriteFile(filepath.Join(dir, ".terraform.lock.hcl"), []byte(lock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	filename := filepath.Join(dir, "main.tf")
+	content := `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}
+
+resource "random_string" "foo" {}
+`
+	runner := testRunner(t, map[string]string{filename: content})
+
+	if err := NewTerraformRequiredProvidersRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	helper.AssertChanges(t, map[string]string{
+		filename: `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+    random = {
+      source  = "hashicorp/random"
+      version = "~> 3.6"
+    }
+  }
+}
+
+resource "random_string" "foo" {}
+`,
+	}, runner.Runner.(*helper.Runner).Changes())
+}
+
+func Test_TerraformRequiredProvidersRule_ignored(t *testing.T) {
+	cases := []struct {
+		Name    string
+		Content string
+		Fixed   string
+	}{
+		{
+			Name: "new terraform block",
+			Content: `
+resource "aws_instance" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+			Fixed: `terraform {
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "aws_instance" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+		},
+		{
+			Name: "existing terraform block",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}
+
+resource "aws_instance" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+			Fixed: `
+terraform {
+  required_version = ">= 1.0"
+  required_providers {
+    aws = {
+      source = "hashicorp/aws"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+
+resource "aws_instance" "foo" {}
+
+resource "random_string" "foo" {}
+`,
+		},
+	}
+
+	rule := NewTerraformRequiredProvidersRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			// The fix of the first issue, which would create the block on its own, is discarded.
+			runner := newIgnoringRunner(t, map[string]string{"module.tf": tc.Content}, "Missing version constraint for provider \"aws\" in `required_providers`")
+
+			if err := rule.Check(terraform.NewRunner(runner)); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertChanges(t, map[string]string{"module.tf": tc.Fixed}, runner.Changes())
+		})
+	}
+}
@@ -1,12 +1,17 @@
 package rules
 
 import (
+	"bytes"
+	"fmt"
 	"path/filepath"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
 )
 
 // TerraformRequiredVersionRule checks whether a terraform version has required_version attribute
@@ -14,6 +19,19 @@ type TerraformRequiredVersionRule struct {
 	tflint.DefaultRule
 }
 
+type terraformRequiredVersionRuleConfig struct {
+	// RequireUpperBound specifies whether required_version must have an upper bound
+	RequireUpperBound bool `hclext:"require_upper_bound,optional"`
+	// ForbidLoneMinimum specifies whether a single ">=" or ">" constraint is disallowed
+	ForbidLoneMinimum bool `hclext:"forbid_lone_minimum,optional"`
+	// MinimumVersion is the floor that the lowest allowed version must be at or above
+	MinimumVersion string `hclext:"minimum_version,optional"`
+	// ForbidExactInModules specifies whether exact versions are disallowed in modules without a backend
+	ForbidExactInModules bool `hclext:"forbid_exact_in_modules,optional"`
+	// DefaultConstraint is the constraint inserted by the fix when required_version is missing
+	DefaultConstraint string `hclext:"default_constraint,optional"`
+}
+
 // NewTerraformRequiredVersionRule returns new rule with default attributes
 func NewTerraformRequiredVersionRule() *TerraformRequiredVersionRule {
 	return &TerraformRequiredVersionRule{}
@@ -39,7 +57,7 @@ func (r *TerraformRequiredVersionRule) Link() string {
 	return project.ReferenceLink(r.Name())
 }
 
-// Check Checks whether required_version is set
+// Check Checks whether required_version is set and follows the configured style
 func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 	path, err := runner.GetModulePath()
 	if err != nil {
@@ -50,6 +68,18 @@ func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 		return nil
 	}
 
+	config := terraformRequiredVersionRuleConfig{}
+	if err := runner.DecodeRuleConfig(r.Name(), &config); err != nil {
+		return err
+	}
+	var floor *version.Version
+	if config.MinimumVersion != "" {
+		floor, err = version.NewVersion(config.MinimumVersion)
+		if err != nil {
+			return fmt.Errorf("invalid minimum_version %q: %w", config.MinimumVersion, err)
+		}
+	}
+
 	files, err := runner.GetFiles()
 	if err != nil {
 		return err
@@ -65,6 +95,10 @@ func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 				Type: "terraform",
 				Body: &hclext.BodySchema{
 					Attributes: []hclext.AttributeSchema{{Name: "required_version"}},
+					Blocks: []hclext.BlockSchema{
+						{Type: "backend", LabelNames: []string{"type"}},
+						{Type: "cloud"},
+					},
 				},
 			},
 		},
@@ -74,18 +108,30 @@ func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 	}
 
 	var exists bool
+	// Modules without a backend are expected to be called from other modules.
+	reusable := true
 
 	for _, block := range body.Blocks {
 		_, ok := block.Body.Attributes["required_version"]
 		exists = exists || ok
+		reusable = reusable && len(block.Body.Blocks) == 0
 	}
 
 	if exists {
+		for _, block := range body.Blocks {
+			attr, ok := block.Body.Attributes["required_version"]
+			if !ok {
+				continue
+			}
+			if err := r.checkConstraints(runner, attr, config, floor, reusable); err != nil {
+				return err
+			}
+		}
 		return nil
 	}
 
 	if len(body.Blocks) > 0 {
-		return r.emitIssue(body.Blocks[0].DefRange, runner)
+		return r.emitIssue(body.Blocks[0].DefRange, runner, config)
 	}
 
 	// If there are no "terraform" blocks, create a hcl.Range from the files
@@ -101,7 +147,7 @@ func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 			Filename: file,
 			Start:    hcl.InitialPos,
 			End:      hcl.InitialPos,
-		}, runner)
+		}, runner, config)
 	}
 
 	moduleDirectory := filepath.Dir(file)
@@ -114,21 +160,99 @@ func (r *TerraformRequiredVersionRule) Check(runner tflint.Runner) error {
 				Filename: filename,
 				Start:    hcl.InitialPos,
 				End:      hcl.InitialPos,
-			}, runner)
+			}, runner, config)
 		}
 	}
 
 	// If none of those are found, point to a nonexistent terraform.tf per the style guide
 	return r.emitIssue(hcl.Range{
 		Filename: filepath.Join(moduleDirectory, "terraform.tf"),
-	}, runner)
+	}, runner, config)
 }
 
-// emitIssue emits issue for missing terraform require version
-func (r *TerraformRequiredVersionRule) emitIssue(missingRange hcl.Range, runner tflint.Runner) error {
-	return runner.EmitIssue(
+// emitIssue emits issue for missing terraform require version.
+// The fix inserts the default constraint into the "terraform" block at the range,
+// or adds a new "terraform" block at the top of the file if there is no block.
+func (r *TerraformRequiredVersionRule) emitIssue(missingRange hcl.Range, runner tflint.Runner, config terraformRequiredVersionRuleConfig) error {
+	return runner.EmitIssueWithFix(
 		r,
 		`terraform "required_version" attribute is required`,
 		missingRange,
+		func(f tflint.Fixer) error {
+			if config.DefaultConstraint == "" {
+				return tflint.ErrFixNotSupported
+			}
+
+			files, err := runner.GetFiles()
+			if err != nil {
+				return err
+			}
+			file, exists := files[missingRange.Filename]
+			if !exists {
+				// The file pointed to does not exist, and the fixer cannot create new files.
+				return tflint.ErrFixNotSupported
+			}
+			body, ok := file.Body.(*hclsyntax.Body)
+			if !ok {
+				return tflint.ErrFixNotSupported
+			}
+			attr := fmt.Sprintf("required_version = %q\n", config.DefaultConstraint)
+
+			for _, block := range body.Blocks {
+				if block.Type == "terraform" && block.DefRange().Start.Byte == missingRange.Start.Byte {
+					return f.InsertTextAfter(block.OpenBraceRange, "\n"+attr)
+				}
+			}
+
+			block := fmt.Sprintf("terraform {\n%s}\n", attr)
+			if len(file.Bytes) > 0 && !bytes.HasPrefix(file.Bytes, []byte("\n")) {
+				block += "\n"
+			}
+			return f.InsertTextBefore(missingRange, block)
+		},
 	)
 }
+
+// checkConstraints checks whether required_version is satisfiable and follows the configured style
+func (r *TerraformRequiredVersionRule) checkConstraints(runner tflint.Runner, attr *hclext.Attribute, config terraformRequiredVersionRuleConfig, floor *version.Version, reusable bool) error {
+	return runner.EvaluateExpr(attr.Expr, func(v string) error {
+		constraints, err := version.NewConstraint(v)
+		if err != nil {
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("required_version %q is invalid: %s", v, err),
+				attr.Expr.Range(),
+			)
+		}
+
+		lowest := terraform.LowestVersion(constraints)
+		if lowest == nil {
+			return runner.EmitIssue(
+				r,
+				fmt.Sprintf("required_version %q cannot be satisfied by any version", v),
+				attr.Expr.Range(),
+			)
+		}
+
+		operators := map[string]bool{}
+		for _, c := range constraints {
+			operators[terraform.ConstraintOperator(c)] = true
+		}
+		loneMinimum := len(constraints) == 1 && (operators[">="] || operators[">"])
+
+		var message string
+		switch {
+		case config.RequireUpperBound && !terraform.HasUpperBound(constraints):
+			message = fmt.Sprintf("required_version %q should specify an upper bound", v)
+		case config.ForbidLoneMinimum && loneMinimum:
+			message = fmt.Sprintf(`required_version %q should not use %q alone`, v, terraform.ConstraintOperator(constraints[0]))
+		case floor != nil && lowest.LessThan(floor):
+			message = fmt.Sprintf("required_version %q allows versions below the minimum version %s", v, floor)
+		case config.ForbidExactInModules && reusable && len(constraints) == 1 && operators["="]:
+			message = fmt.Sprintf("required_version %q should not pin an exact version in a reusable module", v)
+		default:
+			return nil
+		}
+		return runner.EmitIssue(r, message, attr.Expr.Range())
+	}, nil)
+}
@@ -192,3 +192,313 @@ func Test_TerraformRequiredVersionRuleMultipleFiles(t *testing.T) {
 		})
 	}
 }
+
+func Test_TerraformRequiredVersionRuleConstraints(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Config   string
+		Expected helper.Issues
+	}{
+		{
+			Name: "unsatisfiable",
+			Content: `
+terraform {
+  required_version = ">= 1.5, < 1.3"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version ">= 1.5, < 1.3" cannot be satisfied by any version`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 37},
+					},
+				},
+			},
+		},
+		{
+			Name: "no style config",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "upper bound",
+			Config: `
+rule "terraform_required_version" {
+  enabled             = true
+  require_upper_bound = true
+}`,
+			Content: `
+terraform {
+  required_version = ">= 1.5, != 1.6.0"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version ">= 1.5, != 1.6.0" should specify an upper bound`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 40},
+					},
+				},
+			},
+		},
+		{
+			Name: "upper bound with major version only pessimistic constraint",
+			Config: `
+rule "terraform_required_version" {
+  enabled             = true
+  require_upper_bound = true
+}`,
+			Content: `
+terraform {
+  required_version = "~> 1"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version "~> 1" should specify an upper bound`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 28},
+					},
+				},
+			},
+		},
+		{
+			Name: "upper bound with pessimistic constraint",
+			Config: `
+rule "terraform_required_version" {
+  enabled             = true
+  require_upper_bound = true
+}`,
+			Content: `
+terraform {
+  required_version = "~> 1.5"
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "lone minimum",
+			Config: `
+rule "terraform_required_version" {
+  enabled             = true
+  forbid_lone_minimum = true
+}`,
+			Content: `
+terraform {
+  required_version = ">= 1.5"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version ">= 1.5" should not use ">=" alone`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "minimum version",
+			Config: `
+rule "terraform_required_version" {
+  enabled         = true
+  minimum_version = "1.5.0"
+}`,
+			Content: `
+terraform {
+  required_version = "~> 1.3"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version "~> 1.3" allows versions below the minimum version 1.5.0`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 30},
+					},
+				},
+			},
+		},
+		{
+			Name: "exact version in a reusable module",
+			Config: `
+rule "terraform_required_version" {
+  enabled                 = true
+  forbid_exact_in_modules = true
+}`,
+			Content: `
+terraform {
+  required_version = "1.5.7"
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformRequiredVersionRule(),
+					Message: `required_version "1.5.7" should not pin an exact version in a reusable module`,
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 3, Column: 22},
+						End:      hcl.Pos{Line: 3, Column: 29},
+					},
+				},
+			},
+		},
+		{
+			Name: "exact version in a root module",
+			Config: `
+rule "terraform_required_version" {
+  enabled                 = true
+  forbid_exact_in_modules = true
+}`,
+			Content: `
+terraform {
+  required_version = "1.5.7"
+
+  backend "s3" {}
+}`,
+			Expected: helper.Issues{},
+		},
+	}
+
+	rule := NewTerraformRequiredVersionRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			runner := helper.TestRunner(t, map[string]string{"module.tf": tc.Content, ".tflint.hcl": tc.Config})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatal(err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+		})
+	}
+}
+
+func Test_TerraformRequiredVersionRuleFix(t *testing.T) {
+	cases := []struct {
+		Name   string
+		Files  map[string]string
+		Config string
+		Fixed  map[string]string
+	}{
+		{
+			Name: "no default constraint",
+			Files: map[string]string{
+				"module.tf": `
+terraform {}
+`,
+			},
+			Fixed: map[string]string{},
+		},
+		{
+			Name: "terraform block",
+			Files: map[string]string{
+				"module.tf": `
+terraform {
+  backend "s3" {}
+}
+`,
+			},
+			Config: `
+rule "terraform_required_version" {
+  enabled            = true
+  default_constraint = ">= 1.5"
+}`,
+			Fixed: map[string]string{
+				"module.tf": `
+terraform {
+  required_version = ">= 1.5"
+
+  backend "s3" {}
+}
+`,
+			},
+		},
+		{
+			Name: "empty terraform block",
+			Files: map[string]string{
+				"module.tf": `
+terraform {}
+`,
+			},
+			Config: `
+rule "terraform_required_version" {
+  enabled            = true
+  default_constraint = ">= 1.5"
+}`,
+			Fixed: map[string]string{
+				"module.tf": `
+terraform {
+  required_version = ">= 1.5"
+}
+`,
+			},
+		},
+		{
+			Name: "no terraform block",
+			Files: map[string]string{
+				"modules/foo/main.tf": `resource "null_resource" "foo" {}
+`,
+				"modules/foo/terraform.tf": "",
+			},
+			Config: `
+rule "terraform_required_version" {
+  enabled            = true
+  default_constraint = ">= 1.5"
+}`,
+			Fixed: map[string]string{
+				"modules/foo/terraform.tf": `terraform {
+  required_version = ">= 1.5"
+}
+`,
+			},
+		},
+		{
+			Name: "no terraform.tf or main.tf",
+			Files: map[string]string{
+				"modules/foo/variables.tf": "",
+				"modules/foo/outputs.tf":   "",
+			},
+			Config: `
+rule "terraform_required_version" {
+  enabled            = true
+  default_constraint = ">= 1.5"
+}`,
+			Fixed: map[string]string{},
+		},
+	}
+
+	rule := NewTerraformRequiredVersionRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			files := map[string]string{".tflint.hcl": tc.Config}
+			for filename, content := range tc.Files {
+				files[filepath.FromSlash(filename)] = content
+			}
+			runner := helper.TestRunner(t, files)
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatal(err)
+			}
+
+			want := map[string]string{}
+			for filename, content := range tc.Fixed {
+				want[filepath.FromSlash(filename)] = content
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
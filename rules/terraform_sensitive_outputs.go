@@ -0,0 +1,297 @@
+package rules
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/hclext"
+	tfsdk "github.com/terraform-linters/tflint-plugin-sdk/terraform"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/addrs"
+	"github.com/terraform-linters/tflint-plugin-sdk/terraform/lang"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+)
+
+// TerraformSensitiveOutputsRule checks whether outputs exposing sensitive values are marked as sensitive
+type TerraformSensitiveOutputsRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformSensitiveOutputsRule returns a new rule
+func NewTerraformSensitiveOutputsRule() *TerraformSensitiveOutputsRule {
+	return &TerraformSensitiveOutputsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformSensitiveOutputsRule) Name() string {
+	return "terraform_sensitive_outputs"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformSensitiveOutputsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformSensitiveOutputsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformSensitiveOutputsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check follows references from outputs through locals and emits issues for outputs
+// that expose sensitive variables or sensitive module outputs without being marked as sensitive
+func (r *TerraformSensitiveOutputsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	body, err := runner.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type:       "variable",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "sensitive"}},
+				},
+			},
+			{
+				Type:       "output",
+				LabelNames: []string{"name"},
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{
+						{Name: "value"},
+						{Name: "sensitive"},
+					},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return err
+	}
+
+	locals, diags := runner.GetLocals()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	calls, diags := runner.GetModuleCalls()
+	if diags.HasErrors() {
+		return diags
+	}
+
+	sensitiveVars := map[string]bool{}
+	for _, variable := range body.Blocks.OfType("variable") {
+		sensitive, exists := variable.Body.Attributes["sensitive"]
+		if !exists {
+			continue
+		}
+		if val, _ := staticBool(sensitive.Expr); val {
+			sensitiveVars[variable.Labels[0]] = true
+		}
+	}
+	if len(sensitiveVars) == 0 && len(calls) == 0 {
+		return nil
+	}
+
+	tracer := &sensitiveValueTracer{
+		variables: sensitiveVars,
+		locals:    locals,
+		calls:     map[string]*terraform.ModuleCall{},
+		outputs:   map[string]map[string]*terraform.ModuleOutput{},
+	}
+	for _, call := range calls {
+		tracer.calls[call.Name] = call
+	}
+
+	for _, output := range body.Blocks.OfType("output") {
+		value, exists := output.Body.Attributes["value"]
+		if !exists {
+			continue
+		}
+		sensitive, sensitiveExists := output.Body.Attributes["sensitive"]
+		if sensitiveExists {
+			// Outputs marked as sensitive, or whose sensitivity cannot be determined statically, are ignored.
+			if val, known := staticBool(sensitive.Expr); val || !known {
+				continue
+			}
+		}
+
+		chain := tracer.trace(value.Expr, map[string]bool{})
+		if chain == nil {
+			continue
+		}
+
+		if err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf(`output "%s" exposes a sensitive value without sensitive = true: %s`, output.Labels[0], strings.Join(chain, " -> ")),
+			value.Expr.Range(),
+			func(f tflint.Fixer) error {
+				if tfsdk.IsJSONFilename(value.Range.Filename) {
+					return tflint.ErrFixNotSupported
+				}
+				if sensitiveExists {
+					return f.ReplaceText(sensitive.Expr.Range(), "true")
+				}
+				file, err := runner.GetFile(value.Range.Filename)
+				if err != nil {
+					return err
+				}
+				if block := enclosingBlock(file, value.Range); block != nil && block.OpenBraceRange.Start.Line == block.CloseBraceRange.Start.Line {
+					// Single-line blocks can only have one argument, so the block is expanded to multiple lines.
+					return f.ReplaceText(
+						hcl.Range{Filename: value.Range.Filename, Start: block.OpenBraceRange.End, End: block.CloseBraceRange.Start},
+						"\n", f.TextAt(value.Range), "\nsensitive = true\n",
+					)
+				}
+				return f.InsertTextAfter(value.Range, "\nsensitive = true")
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sensitiveValueTracer follows references through locals to sensitive variables and module outputs
+type sensitiveValueTracer struct {
+	variables map[string]bool
+	locals    map[string]*terraform.Local
+	calls     map[string]*terraform.ModuleCall
+	// outputs caches outputs of called modules. It is nil for modules whose outputs cannot be loaded.
+	outputs map[string]map[string]*terraform.ModuleOutput
+}
+
+// trace returns the chain of references from the expression to a sensitive variable or module output,
+// or nil if the expression does not refer to any sensitive value
+func (t *sensitiveValueTracer) trace(expr hcl.Expression, visited map[string]bool) []string {
+	unmarked := nonsensitiveRanges(expr)
+
+RefLoop:
+	for _, ref := range lang.ReferencesInExpr(expr) {
+		for _, rng := range unmarked {
+			if rng.ContainsOffset(ref.SourceRange.Start.Byte) {
+				// Values passed to nonsensitive() are intentionally exposed.
+				continue RefLoop
+			}
+		}
+
+		switch sub := ref.Subject.(type) {
+		case addrs.InputVariable:
+			if t.variables[sub.Name] {
+				return []string{sub.String()}
+			}
+		case addrs.LocalValue:
+			if visited[sub.Name] {
+				continue
+			}
+			visited[sub.Name] = true
+
+			local, exists := t.locals[sub.Name]
+			if !exists {
+				continue
+			}
+			if chain := t.trace(local.Attribute.Expr, visited); chain != nil {
+				return append([]string{sub.String()}, chain...)
+			}
+		case addrs.ModuleCallInstanceOutput:
+			if output, exists := t.moduleOutputs(sub.Call.Call.Name)[sub.Name]; exists && output.Sensitive {
+				return []string{sub.String()}
+			}
+		case addrs.ModuleCall:
+			if t.hasSensitiveOutput(sub.Name) {
+				return []string{sub.String()}
+			}
+		case addrs.ModuleCallInstance:
+			if t.hasSensitiveOutput(sub.Call.Name) {
+				return []string{sub.String()}
+			}
+		}
+	}
+
+	return nil
+}
+
+// moduleOutputs returns outputs of the called module. Modules are loaded only when they are referenced.
+// Outputs of modules that are not installed, or that have errors, are not traced.
+func (t *sensitiveValueTracer) moduleOutputs(name string) map[string]*terraform.ModuleOutput {
+	if outputs, loaded := t.outputs[name]; loaded {
+		return outputs
+	}
+	t.outputs[name] = nil
+
+	call, exists := t.calls[name]
+	if !exists {
+		return nil
+	}
+	dir, ok := terraform.ModuleDir(call)
+	if !ok {
+		return nil
+	}
+	outputs, diags := terraform.LoadModuleOutputs(dir)
+	if diags.HasErrors() {
+		return nil
+	}
+	t.outputs[name] = outputs
+	return outputs
+}
+
+// hasSensitiveOutput returns whether the called module has any sensitive outputs.
+// References to the whole module object expose all of its outputs.
+func (t *sensitiveValueTracer) hasSensitiveOutput(name string) bool {
+	for _, output := range t.moduleOutputs(name) {
+		if output.Sensitive {
+			return true
+		}
+	}
+	return false
+}
+
+// enclosingBlock returns the top-level block in the file that contains the range
+func enclosingBlock(file *hcl.File, rng hcl.Range) *hclsyntax.Block {
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		return nil
+	}
+	for _, block := range body.Blocks {
+		if block.Range().ContainsOffset(rng.Start.Byte) {
+			return block
+		}
+	}
+	return nil
+}
+
+// nonsensitiveRanges returns ranges of arguments passed to nonsensitive() in the expression
+func nonsensitiveRanges(expr hcl.Expression) []hcl.Range {
+	ranges := []hcl.Range{}
+
+	node, ok := expr.(hclsyntax.Node)
+	if !ok {
+		return ranges
+	}
+	hclsyntax.VisitAll(node, func(n hclsyntax.Node) hcl.Diagnostics {
+		if call, ok := n.(*hclsyntax.FunctionCallExpr); ok && call.Name == "nonsensitive" {
+			for _, arg := range call.Args {
+				ranges = append(ranges, arg.Range())
+			}
+		}
+		return nil
+	})
+
+	return ranges
+}
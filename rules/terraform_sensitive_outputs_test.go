@@ -0,0 +1,291 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformSensitiveOutputsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "direct reference",
+			Content: `
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+output "password" {
+  value = var.password
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputsRule(),
+					Message: `output "password" exposes a sensitive value without sensitive = true: var.password`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 8, Column: 11},
+						End:      hcl.Pos{Line: 8, Column: 23},
+					},
+				},
+			},
+			Fixed: `
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+output "password" {
+  value     = var.password
+  sensitive = true
+}`,
+		},
+		{
+			Name: "single-line block",
+			Content: `
+variable "password" {
+  sensitive = true
+}
+
+output "password" { value = var.password }`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputsRule(),
+					Message: `output "password" exposes a sensitive value without sensitive = true: var.password`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 6, Column: 29},
+						End:      hcl.Pos{Line: 6, Column: 41},
+					},
+				},
+			},
+			Fixed: `
+variable "password" {
+  sensitive = true
+}
+
+output "password" {
+  value     = var.password
+  sensitive = true
+}`,
+		},
+		{
+			Name: "through locals",
+			Content: `
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  credentials       = "admin:${var.password}"
+  connection_string = "postgres://${local.credentials}@localhost"
+}
+
+output "connection_string" {
+  value     = local.connection_string
+  sensitive = false
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputsRule(),
+					Message: `output "connection_string" exposes a sensitive value without sensitive = true: local.connection_string -> local.credentials -> var.password`,
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 13, Column: 15},
+						End:      hcl.Pos{Line: 13, Column: 38},
+					},
+				},
+			},
+			Fixed: `
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+locals {
+  credentials       = "admin:${var.password}"
+  connection_string = "postgres://${local.credentials}@localhost"
+}
+
+output "connection_string" {
+  value     = local.connection_string
+  sensitive = true
+}`,
+		},
+		{
+			Name: "sensitive, nonsensitive, and non-sensitive values",
+			Content: `
+variable "password" {
+  type      = string
+  sensitive = true
+}
+
+variable "username" {
+  type = string
+}
+
+locals {
+  a = local.b
+  b = local.a
+}
+
+output "password" {
+  value     = var.password
+  sensitive = true
+}
+
+output "password_length" {
+  value = length(nonsensitive(var.password))
+}
+
+output "username" {
+  value = var.username
+}
+
+output "cycle" {
+  value = local.a
+}`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "JSON",
+			JSON: true,
+			Content: `
+{
+  "variable": {
+    "password": {
+      "type": "string",
+      "sensitive": true
+    }
+  },
+  "output": {
+    "password": {
+      "value": "${var.password}"
+    }
+  }
+}`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformSensitiveOutputsRule(),
+					Message: `output "password" exposes a sensitive value without sensitive = true: var.password`,
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 11, Column: 16},
+						End:      hcl.Pos{Line: 11, Column: 33},
+					},
+				},
+			},
+		},
+	}
+
+	rule := NewTerraformSensitiveOutputsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := testRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Runner.(*helper.Runner).Changes())
+		})
+	}
+}
+
+func Test_TerraformSensitiveOutputsRuleModuleOutputs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, "modules", "db"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	module := `
+output "password" {
+  value     = random_password.main.result
+  sensitive = true
+}
+
+output "endpoint" {
+  value = aws_db_instance.main.endpoint
+}`
+	if err := os.WriteFile(filepath.Join(dir, "modules", "db", "outputs.tf"), []byte(module), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	content := `
+module "db" {
+  source = "./modules/db"
+}
+
+module "cache" {
+  source = "./modules/cache"
+}
+
+locals {
+  connection_string = "postgres://admin:${module.db.password}@${module.db.endpoint}"
+}
+
+output "connection_string" {
+  value = local.connection_string
+}
+
+output "endpoint" {
+  value = module.db.endpoint
+}
+
+output "db" {
+  value = module.db
+}
+
+output "cache" {
+  value = module.cache.password
+}`
+
+	runner := testRunner(t, map[string]string{filepath.Join(dir, "main.tf"): content})
+
+	if err := NewTerraformSensitiveOutputsRule().Check(runner); err != nil {
+		t.Fatalf("Unexpected error occurred: %s", err)
+	}
+
+	expected := helper.Issues{
+		{
+			Rule:    NewTerraformSensitiveOutputsRule(),
+			Message: `output "connection_string" exposes a sensitive value without sensitive = true: local.connection_string -> module.db.password`,
+			Range: hcl.Range{
+				Filename: filepath.Join(dir, "main.tf"),
+				Start:    hcl.Pos{Line: 15, Column: 11},
+				End:      hcl.Pos{Line: 15, Column: 34},
+			},
+		},
+		{
+			Rule:    NewTerraformSensitiveOutputsRule(),
+			Message: `output "db" exposes a sensitive value without sensitive = true: module.db`,
+			Range: hcl.Range{
+				Filename: filepath.Join(dir, "main.tf"),
+				Start:    hcl.Pos{Line: 23, Column: 11},
+				End:      hcl.Pos{Line: 23, Column: 20},
+			},
+		},
+	}
+	helper.AssertIssues(t, expected, runner.Runner.(*helper.Runner).Issues)
+}
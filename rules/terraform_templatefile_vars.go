@@ -0,0 +1,152 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// TerraformTemplatefileVarsRule checks whether templatefile() calls pass exactly the variables the template uses
+type TerraformTemplatefileVarsRule struct {
+	tflint.DefaultRule
+}
+
+// NewTerraformTemplatefileVarsRule returns a new rule
+func NewTerraformTemplatefileVarsRule() *TerraformTemplatefileVarsRule {
+	return &TerraformTemplatefileVarsRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformTemplatefileVarsRule) Name() string {
+	return "terraform_templatefile_vars"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformTemplatefileVarsRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformTemplatefileVarsRule) Severity() tflint.Severity {
+	return tflint.WARNING
+}
+
+// Link returns the rule reference link
+func (r *TerraformTemplatefileVarsRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check parses templates passed to templatefile() and compares their placeholders with the given vars
+func (r *TerraformTemplatefileVarsRule) Check(rr tflint.Runner) error {
+	runner := rr.(*terraform.Runner)
+
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	diags := runner.WalkFunctionCalls(func(call *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+		if call.Name != "templatefile" || len(call.Args) != 2 {
+			return nil
+		}
+		if err := r.checkTemplatefile(runner, call); err != nil {
+			return hcl.Diagnostics{
+				{
+					Severity: hcl.DiagError,
+					Summary:  "failed to call EmitIssue()",
+					Detail:   err.Error(),
+				},
+			}
+		}
+		return nil
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	return nil
+}
+
+func (r *TerraformTemplatefileVarsRule) checkTemplatefile(runner tflint.Runner, call *hclsyntax.FunctionCallExpr) error {
+	filename, ok := resolveFilePath(call.Args[0])
+	if !ok {
+		return nil
+	}
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		// The template may not exist until it is generated by another resource.
+		// Missing files are reported by terraform_file_paths.
+		return nil
+	}
+	template, diags := hclsyntax.ParseTemplate(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil
+	}
+
+	vars, ok := call.Args[1].(*hclsyntax.ObjectConsExpr)
+	if !ok {
+		// Vars built with functions or references cannot be checked statically.
+		return nil
+	}
+	keys := map[string]hcl.Range{}
+	for _, item := range vars.Items {
+		key, diags := item.KeyExpr.Value(nil)
+		if diags.HasErrors() || !key.IsKnown() || key.IsNull() || key.Type() != cty.String {
+			return nil
+		}
+		keys[key.AsString()] = item.KeyExpr.Range()
+	}
+
+	used := map[string]bool{}
+	for _, traversal := range template.Variables() {
+		used[traversal.RootName()] = true
+	}
+
+	missing := []string{}
+	for name := range used {
+		if _, exists := keys[name]; !exists {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	for _, name := range missing {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("Template variable %q used in %s is not provided in vars", name, filepath.ToSlash(filename)),
+			vars.Range(),
+		); err != nil {
+			return err
+		}
+	}
+
+	unused := []string{}
+	for name := range keys {
+		if !used[name] {
+			unused = append(unused, name)
+		}
+	}
+	sort.Strings(unused)
+	for _, name := range unused {
+		if err := runner.EmitIssue(
+			r,
+			fmt.Sprintf("%q is not used in %s", name, filepath.ToSlash(filename)),
+			keys[name],
+		); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
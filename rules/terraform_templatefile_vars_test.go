@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformTemplatefileVarsRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		Template string
+		Expected func(dir string) helper.Issues
+	}{
+		{
+			Name: "consistent vars",
+			Content: `
+locals {
+  user_data = templatefile("${path.module}/templates/user_data.tftpl", {
+    name  = var.name
+    ports = [80, 443]
+  })
+}`,
+			Template: `
+hostname ${name}
+%{ for port in ports ~}
+allow ${port}
+%{ endfor ~}
+`,
+			Expected: func(string) helper.Issues { return helper.Issues{} },
+		},
+		{
+			Name: "missing and unused vars",
+			Content: `
+locals {
+  user_data = templatefile("${path.module}/templates/user_data.tftpl", {
+    name = var.name
+    port = 80
+  })
+}`,
+			Template: `
+hostname ${name}
+domain ${domain}
+user ${upper(user)}
+`,
+			Expected: func(dir string) helper.Issues {
+				template := filepath.ToSlash(filepath.Join(dir, "templates", "user_data.tftpl"))
+				return helper.Issues{
+					{
+						Rule:    NewTerraformTemplatefileVarsRule(),
+						Message: fmt.Sprintf(`Template variable "domain" used in %s is not provided in vars`, template),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 3, Column: 72},
+							End:      hcl.Pos{Line: 6, Column: 4},
+						},
+					},
+					{
+						Rule:    NewTerraformTemplatefileVarsRule(),
+						Message: fmt.Sprintf(`Template variable "user" used in %s is not provided in vars`, template),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 3, Column: 72},
+							End:      hcl.Pos{Line: 6, Column: 4},
+						},
+					},
+					{
+						Rule:    NewTerraformTemplatefileVarsRule(),
+						Message: fmt.Sprintf(`"port" is not used in %s`, template),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 5, Column: 5},
+							End:      hcl.Pos{Line: 5, Column: 9},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "relative path without path.module",
+			Content: `
+locals {
+  user_data = templatefile("templates/user_data.tftpl", {})
+}`,
+			Template: `hostname ${name}`,
+			Expected: func(dir string) helper.Issues {
+				return helper.Issues{
+					{
+						Rule:    NewTerraformTemplatefileVarsRule(),
+						Message: fmt.Sprintf(`Template variable "name" used in %s is not provided in vars`, filepath.ToSlash(filepath.Join(dir, "templates", "user_data.tftpl"))),
+						Range: hcl.Range{
+							Filename: filepath.Join(dir, "main.tf"),
+							Start:    hcl.Pos{Line: 3, Column: 57},
+							End:      hcl.Pos{Line: 3, Column: 59},
+						},
+					},
+				}
+			},
+		},
+		{
+			Name: "dynamic path and vars",
+			Content: `
+locals {
+  a = templatefile(var.template, { name = var.name })
+  b = templatefile("${path.module}/templates/user_data.tftpl", var.vars)
+  c = templatefile("${path.module}/templates/missing.tftpl", { name = var.name })
+}`,
+			Template: `hostname ${domain}`,
+			Expected: func(string) helper.Issues { return helper.Issues{} },
+		},
+	}
+
+	rule := NewTerraformTemplatefileVarsRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			dir := t.TempDir()
+			if err := os.Mkdir(filepath.Join(dir, "templates"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(dir, "templates", "user_data.tftpl"), []byte(tc.Template), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			runner := testRunner(t, map[string]string{filepath.Join(dir, "main.tf"): tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected(dir), runner.Runner.(*helper.Runner).Issues)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
 	"github.com/terraform-linters/tflint-ruleset-terraform/project"
 	"github.com/terraform-linters/tflint-ruleset-terraform/terraform"
@@ -57,52 +58,20 @@ func (r *TerraformUnusedRequiredProvidersRule) Check(rr tflint.Runner) error {
 		return diags
 	}
 
-	files, err := runner.GetFiles()
-	if err != nil {
-		return err
-	}
-
-	requiredProviders := hcl.Attributes{}
-	for _, file := range files {
-		content, _, schemaDiags := file.Body.PartialContent(&hcl.BodySchema{
-			Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
-		})
-		diags = diags.Extend(schemaDiags)
-		if diags.HasErrors() {
-			continue
-		}
-
-		for _, block := range content.Blocks {
-			content, _, schemaDiags = block.Body.PartialContent(&hcl.BodySchema{
-				Blocks: []hcl.BlockHeaderSchema{{Type: "required_providers"}},
-			})
-			diags = diags.Extend(schemaDiags)
-			if diags.HasErrors() {
-				continue
-			}
-
-			for _, block := range content.Blocks {
-				attributes, attrDiags := block.Body.JustAttributes()
-				for k, v := range attributes {
-					requiredProviders[k] = v
-				}
-				diags = diags.Extend(attrDiags)
-				if diags.HasErrors() {
-					continue
-				}
-			}
-		}
-	}
+	requiredProviders, diags := runner.GetRequiredProviders()
 	if diags.HasErrors() {
 		return diags
 	}
 
 	for _, required := range requiredProviders {
 		if _, exists := providerRefs[required.Name]; !exists {
-			if err := runner.EmitIssue(
+			if err := runner.EmitIssueWithFix(
 				r,
 				fmt.Sprintf("provider '%s' is declared in required_providers but not used by the module", required.Name),
 				required.Range,
+				func(f tflint.Fixer) error {
+					return r.removeRequiredProvider(f, runner, required, providerRefs)
+				},
 			); err != nil {
 				return err
 			}
@@ -111,3 +80,45 @@ func (r *TerraformUnusedRequiredProvidersRule) Check(rr tflint.Runner) error {
 
 	return nil
 }
+
+// removeRequiredProvider removes the given entry from required_providers.
+// If no entry in the block is used, the whole block is removed instead of leaving an empty block.
+func (r *TerraformUnusedRequiredProvidersRule) removeRequiredProvider(f tflint.Fixer, runner *terraform.Runner, required *hcl.Attribute, providerRefs map[string]*terraform.ProviderRef) error {
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	file, exists := files[required.Range.Filename]
+	if !exists {
+		return tflint.ErrFixNotSupported
+	}
+	body, ok := file.Body.(*hclsyntax.Body)
+	if !ok {
+		// Blocks in JSON syntax cannot be rewritten.
+		return tflint.ErrFixNotSupported
+	}
+
+	for _, block := range body.Blocks {
+		if block.Type != "terraform" {
+			continue
+		}
+		for _, nested := range block.Body.Blocks {
+			if nested.Type != "required_providers" {
+				continue
+			}
+			attr, exists := nested.Body.Attributes[required.Name]
+			if !exists || attr.SrcRange.Start.Byte != required.Range.Start.Byte {
+				continue
+			}
+
+			for name := range nested.Body.Attributes {
+				if _, used := providerRefs[name]; used {
+					return f.RemoveAttribute(required)
+				}
+			}
+			return f.RemoveBlock(nested.AsHCLBlock())
+		}
+	}
+
+	return tflint.ErrFixNotSupported
+}
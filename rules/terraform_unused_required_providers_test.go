@@ -12,6 +12,7 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 		Name     string
 		Content  string
 		Expected helper.Issues
+		Fixed    string
 	}{
 		{
 			Name:     "empty",
@@ -174,6 +175,10 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+}
+   `,
 		},
 		{
 			Name: "unused - override",
@@ -209,6 +214,18 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+  required_providers {
+    custom-null = {
+      source = "custom/null"
+    }
+  }
+}
+resource "null_resource" "foo" {
+  provider = custom-null
+}
+   `,
 		},
 		{
 			Name: "unused - module",
@@ -241,6 +258,13 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+}
+module "m" {
+  source = "./m"
+}
+   `,
 		},
 		{
 			Name: "used - unevaluated resource",
@@ -311,6 +335,76 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 					},
 				},
 			},
+			Fixed: `
+terraform {
+}
+terraform {
+  required_providers {
+    null = {
+      source = "hashicorp/null"
+    }
+  }
+}
+resource "null_resource" "foo" {}
+   `,
+		},
+		{
+			Name: "used - local name with underscore",
+			Content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source = "corp/cloud"
+    }
+  }
+}
+
+resource "corp_cloud_instance" "foo" {}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name: "unused - all entries",
+			Content: `
+terraform {
+  required_version = ">= 1.0"
+
+  required_providers {
+    null = {
+      source = "hashicorp/null"
+    }
+    random = {
+      source = "hashicorp/random"
+    }
+  }
+}
+`,
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformUnusedRequiredProvidersRule(),
+					Message: "provider 'null' is declared in required_providers but not used by the module",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 6, Column: 5},
+						End:      hcl.Pos{Line: 8, Column: 6},
+					},
+				},
+				{
+					Rule:    NewTerraformUnusedRequiredProvidersRule(),
+					Message: "provider 'random' is declared in required_providers but not used by the module",
+					Range: hcl.Range{
+						Filename: "module.tf",
+						Start:    hcl.Pos{Line: 9, Column: 5},
+						End:      hcl.Pos{Line: 11, Column: 6},
+					},
+				},
+			},
+			Fixed: `
+terraform {
+  required_version = ">= 1.0"
+
+}
+`,
 		},
 	}
 
@@ -325,6 +419,11 @@ func Test_TerraformUnusedRequiredProvidersRule(t *testing.T) {
 			}
 
 			helper.AssertIssues(t, tc.Expected, runner.Runner.(*helper.Runner).Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want["module.tf"] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Runner.(*helper.Runner).Changes())
 		})
 	}
 }
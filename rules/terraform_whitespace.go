@@ -0,0 +1,262 @@
+package rules
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/terraform-linters/tflint-plugin-sdk/tflint"
+	"github.com/terraform-linters/tflint-ruleset-terraform/project"
+)
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// TerraformWhitespaceRule checks whether files are free of a BOM, CRLF line endings, and stray whitespace
+type TerraformWhitespaceRule struct {
+	tflint.DefaultRule
+}
+
+// sourceLine is a line in a file. The content does not include the line ending.
+type sourceLine struct {
+	start   int
+	content []byte
+	crlf    bool
+	end     int
+}
+
+// NewTerraformWhitespaceRule returns a new rule
+func NewTerraformWhitespaceRule() *TerraformWhitespaceRule {
+	return &TerraformWhitespaceRule{}
+}
+
+// Name returns the rule name
+func (r *TerraformWhitespaceRule) Name() string {
+	return "terraform_whitespace"
+}
+
+// Enabled returns whether the rule is enabled by default
+func (r *TerraformWhitespaceRule) Enabled() bool {
+	return true
+}
+
+// Severity returns the rule severity
+func (r *TerraformWhitespaceRule) Severity() tflint.Severity {
+	return tflint.NOTICE
+}
+
+// Link returns the rule reference link
+func (r *TerraformWhitespaceRule) Link() string {
+	return project.ReferenceLink(r.Name())
+}
+
+// Check checks whether files contain a BOM, CRLF line endings, or stray whitespace
+func (r *TerraformWhitespaceRule) Check(runner tflint.Runner) error {
+	path, err := runner.GetModulePath()
+	if err != nil {
+		return err
+	}
+	if !path.IsRoot() {
+		// This rule does not evaluate child modules.
+		return nil
+	}
+
+	files, err := runner.GetFiles()
+	if err != nil {
+		return err
+	}
+	for name, file := range files {
+		if err := r.checkWhitespace(runner, name, file); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TerraformWhitespaceRule) checkWhitespace(runner tflint.Runner, filename string, file *hcl.File) error {
+	src := file.Bytes
+	if len(src) == 0 {
+		return nil
+	}
+
+	if bytes.HasPrefix(src, utf8BOM) {
+		rng := byteRange(filename, src, 0, len(utf8BOM))
+		if err := runner.EmitIssueWithFix(r, "File starts with a UTF-8 byte order mark", rng, func(f tflint.Fixer) error {
+			return f.Remove(rng)
+		}); err != nil {
+			return err
+		}
+	}
+
+	heredocs, err := heredocRanges(filename, src)
+	if err != nil {
+		return err
+	}
+
+	lines := splitSourceLines(src)
+	crlfs := []hcl.Range{}
+	blanks := 0
+
+	for i, line := range lines {
+		heredoc := inRanges(heredocs, line.start)
+		trimmed := bytes.TrimRight(line.content, " \t")
+		switch {
+		case heredoc:
+			blanks = 0
+		case len(trimmed) == 0:
+			blanks++
+		default:
+			blanks = 0
+		}
+
+		// All but the first of consecutive blank lines are removed together,
+		// so they are skipped here to prevent fixes from overlapping.
+		if blanks > 1 {
+			continue
+		}
+		if line.crlf {
+			crlfs = append(crlfs, byteRange(filename, src, line.start+len(line.content), line.start+len(line.content)+1))
+		}
+		if heredoc {
+			continue
+		}
+
+		if blanks == 1 {
+			if end := blankLinesEnd(lines, heredocs, i+1); end > i+1 {
+				rng := byteRange(filename, src, lines[i+1].start, lines[end-1].end)
+				if err := runner.EmitIssueWithFix(r, "Multiple consecutive blank lines", rng, func(f tflint.Fixer) error {
+					return f.Remove(rng)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+
+		if len(trimmed) < len(line.content) {
+			rng := byteRange(filename, src, line.start+len(trimmed), line.start+len(line.content))
+			if err := runner.EmitIssueWithFix(r, "Line has trailing whitespace", rng, func(f tflint.Fixer) error {
+				return f.Remove(rng)
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(trimmed) > 0 {
+			indent := line.content[:len(line.content)-len(bytes.TrimLeft(line.content, " \t"))]
+			if bytes.ContainsRune(indent, '\t') {
+				rng := byteRange(filename, src, line.start, line.start+len(indent))
+				if err := runner.EmitIssueWithFix(r, "Line is indented with tabs", rng, func(f tflint.Fixer) error {
+					return f.ReplaceText(rng, strings.ReplaceAll(string(indent), "\t", "  "))
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if len(crlfs) > 0 {
+		if err := runner.EmitIssueWithFix(
+			r,
+			fmt.Sprintf("File uses CRLF line endings (%d lines)", len(crlfs)),
+			hcl.Range{Filename: filename, Start: crlfs[0].Start, End: crlfs[0].Start},
+			func(f tflint.Fixer) error {
+				for _, rng := range crlfs {
+					if err := f.Remove(rng); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+		); err != nil {
+			return err
+		}
+	}
+
+	if src[len(src)-1] != '\n' {
+		rng := byteRange(filename, src, len(src), len(src))
+		if err := runner.EmitIssueWithFix(r, "File does not end with a newline", rng, func(f tflint.Fixer) error {
+			return f.InsertTextAfter(rng, "\n")
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// splitSourceLines splits the source into lines.
+// The end of each line includes the line ending.
+func splitSourceLines(src []byte) []sourceLine {
+	lines := []sourceLine{}
+	offset := 0
+	if bytes.HasPrefix(src, utf8BOM) {
+		offset = len(utf8BOM)
+	}
+
+	for offset < len(src) {
+		end := lineEnd(src, offset)
+		content := bytes.TrimSuffix(src[offset:end], []byte("\n"))
+		crlf := bytes.HasSuffix(content, []byte("\r")) && end > offset+len(content)
+		if crlf {
+			content = content[:len(content)-1]
+		}
+		lines = append(lines, sourceLine{start: offset, content: content, crlf: crlf, end: end})
+		offset = end
+	}
+
+	return lines
+}
+
+// blankLinesEnd returns the index of the first non-blank line at or after the given index
+func blankLinesEnd(lines []sourceLine, heredocs []hcl.Range, idx int) int {
+	for ; idx < len(lines); idx++ {
+		if inRanges(heredocs, lines[idx].start) || len(bytes.TrimRight(lines[idx].content, " \t")) > 0 {
+			return idx
+		}
+	}
+	return idx
+}
+
+// heredocRanges returns ranges of heredoc contents and closing markers, in which whitespace is significant.
+// JSON files have no heredocs.
+func heredocRanges(filename string, src []byte) ([]hcl.Range, error) {
+	if strings.HasSuffix(filename, ".json") {
+		return nil, nil
+	}
+
+	tokens, diags := hclsyntax.LexConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	ranges := []hcl.Range{}
+	var open *hclsyntax.Token
+	for i, token := range tokens {
+		switch token.Type {
+		case hclsyntax.TokenOHeredoc:
+			open = &tokens[i]
+		case hclsyntax.TokenCHeredoc:
+			if open != nil {
+				ranges = append(ranges, hcl.Range{Filename: filename, Start: open.Range.End, End: token.Range.End})
+				open = nil
+			}
+		}
+	}
+	return ranges, nil
+}
+
+func inRanges(ranges []hcl.Range, offset int) bool {
+	for _, rng := range ranges {
+		if rng.Start.Byte <= offset && offset < rng.End.Byte {
+			return true
+		}
+	}
+	return false
+}
+
+// byteRange returns the range between the given byte offsets
+func byteRange(filename string, src []byte, start int, end int) hcl.Range {
+	return hcl.Range{Filename: filename, Start: posAt(src, start), End: posAt(src, end)}
+}
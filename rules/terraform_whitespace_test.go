@@ -0,0 +1,197 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/terraform-linters/tflint-plugin-sdk/helper"
+)
+
+func Test_TerraformWhitespaceRule(t *testing.T) {
+	cases := []struct {
+		Name     string
+		Content  string
+		JSON     bool
+		Expected helper.Issues
+		Fixed    string
+	}{
+		{
+			Name: "clean",
+			Content: `
+variable "foo" {
+  type = string
+}
+`,
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "BOM",
+			Content: "\xef\xbb\xbfvariable \"foo\" {}\n",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "File starts with a UTF-8 byte order mark",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 1},
+						End:      hcl.Pos{Line: 1, Column: 4},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {}\n",
+		},
+		{
+			Name:    "CRLF",
+			Content: "variable \"foo\" {\r\n  type = string\r\n}\r\n",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "File uses CRLF line endings (3 lines)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 17},
+						End:      hcl.Pos{Line: 1, Column: 17},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {\n  type = string\n}\n",
+		},
+		{
+			Name:    "tabs and trailing whitespace",
+			Content: "variable \"foo\" {\n\ttype = string  \n}\n",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "Line is indented with tabs",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 2},
+					},
+				},
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "Line has trailing whitespace",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 2, Column: 15},
+						End:      hcl.Pos{Line: 2, Column: 17},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {\n  type = string\n}\n",
+		},
+		{
+			Name:    "missing final newline",
+			Content: "variable \"foo\" {}",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "File does not end with a newline",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 18},
+						End:      hcl.Pos{Line: 1, Column: 18},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {}\n",
+		},
+		{
+			Name:    "multiple blank lines",
+			Content: "variable \"foo\" {}\n\n  \n\nvariable \"bar\" {}\n",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "Multiple consecutive blank lines",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 5, Column: 1},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {}\n\nvariable \"bar\" {}\n",
+		},
+		{
+			Name:    "CRLF with multiple blank lines",
+			Content: "variable \"foo\" {}\r\n\r\n\r\nvariable \"bar\" {}\r\n",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "Multiple consecutive blank lines",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 3, Column: 1},
+						End:      hcl.Pos{Line: 4, Column: 1},
+					},
+				},
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "File uses CRLF line endings (3 lines)",
+					Range: hcl.Range{
+						Filename: "main.tf",
+						Start:    hcl.Pos{Line: 1, Column: 18},
+						End:      hcl.Pos{Line: 1, Column: 18},
+					},
+				},
+			},
+			Fixed: "variable \"foo\" {}\n\nvariable \"bar\" {}\n",
+		},
+		{
+			Name:     "whitespace in heredoc",
+			Content:  "locals {\n  script = <<-EOF\n\techo foo  \n\n\n\tEOF\n}\n",
+			Expected: helper.Issues{},
+		},
+		{
+			Name:    "JSON",
+			JSON:    true,
+			Content: "{\n\t\"variable\": {\"foo\": {}}\n}",
+			Expected: helper.Issues{
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "Line is indented with tabs",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 2, Column: 1},
+						End:      hcl.Pos{Line: 2, Column: 2},
+					},
+				},
+				{
+					Rule:    NewTerraformWhitespaceRule(),
+					Message: "File does not end with a newline",
+					Range: hcl.Range{
+						Filename: "main.tf.json",
+						Start:    hcl.Pos{Line: 3, Column: 2},
+						End:      hcl.Pos{Line: 3, Column: 2},
+					},
+				},
+			},
+			Fixed: "{\n  \"variable\": {\"foo\": {}}\n}\n",
+		},
+	}
+
+	rule := NewTerraformWhitespaceRule()
+
+	for _, tc := range cases {
+		t.Run(tc.Name, func(t *testing.T) {
+			filename := "main.tf"
+			if tc.JSON {
+				filename += ".json"
+			}
+
+			runner := helper.TestRunner(t, map[string]string{filename: tc.Content})
+
+			if err := rule.Check(runner); err != nil {
+				t.Fatalf("Unexpected error occurred: %s", err)
+			}
+
+			helper.AssertIssues(t, tc.Expected, runner.Issues)
+			want := map[string]string{}
+			if tc.Fixed != "" {
+				want[filename] = tc.Fixed
+			}
+			helper.AssertChanges(t, want, runner.Changes())
+		})
+	}
+}
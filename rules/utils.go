@@ -0,0 +1,71 @@
+package rules
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// staticFilePath returns the path given as a string literal or a template that only refers to path.module.
+// path.module is resolved to the directory of the file that contains the expression, so the returned path
+// is relative to the current directory in the same way as Terraform resolves it.
+func staticFilePath(expr hcl.Expression) (filename string, usesPathModule bool, ok bool) {
+	for _, traversal := range expr.Variables() {
+		if traversal.RootName() != "path" || len(traversal) != 2 {
+			return "", false, false
+		}
+		if attr, isAttr := traversal[1].(hcl.TraverseAttr); !isAttr || attr.Name != "module" {
+			return "", false, false
+		}
+		usesPathModule = true
+	}
+
+	val, diags := expr.Value(&hcl.EvalContext{
+		Variables: map[string]cty.Value{
+			"path": cty.ObjectVal(map[string]cty.Value{
+				"module": cty.StringVal(filepath.ToSlash(filepath.Dir(expr.Range().Filename))),
+			}),
+		},
+	})
+	if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+		return "", false, false
+	}
+
+	return filepath.FromSlash(val.AsString()), usesPathModule, true
+}
+
+// resolveFilePath returns the file a static path passed to a file function refers to.
+// Relative paths without path.module are resolved against the module directory rather than the current directory,
+// because that is the file the module author intends, even though Terraform resolves them from the working directory.
+func resolveFilePath(expr hcl.Expression) (string, bool) {
+	filename, usesPathModule, ok := staticFilePath(expr)
+	if !ok {
+		return "", false
+	}
+	if !usesPathModule && !filepath.IsAbs(filename) {
+		filename = filepath.Join(filepath.Dir(expr.Range().Filename), filename)
+	}
+	return filename, true
+}
+
+// matchTypePattern reports whether the type name matches the glob pattern
+func matchTypePattern(pattern string, name string) (bool, error) {
+	matched, err := path.Match(pattern, name)
+	if err != nil {
+		return false, fmt.Errorf("invalid type pattern %q: %w", pattern, err)
+	}
+	return matched, nil
+}
+
+// staticBool returns the value of the expression if it can be decoded as a bool without evaluation.
+// known is false for expressions that refer to other values, or are not bools.
+func staticBool(expr hcl.Expression) (value bool, known bool) {
+	if diags := gohcl.DecodeExpression(expr, nil, &value); diags.HasErrors() {
+		return false, false
+	}
+	return value, true
+}
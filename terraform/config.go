@@ -3,4 +3,6 @@ package terraform
 // Config is the configuration for the ruleset.
 type Config struct {
 	Preset string `hclext:"preset,optional"`
+	// ProviderPrefixes maps resource type prefixes to the local names of the providers that own them
+	ProviderPrefixes map[string]string `hclext:"provider_prefixes,optional"`
 }
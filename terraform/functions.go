@@ -0,0 +1,171 @@
+package terraform
+
+import (
+	"github.com/hashicorp/go-version"
+)
+
+// Function represents the signature of a built-in function.
+type Function struct {
+	// MinArgs is the number of required arguments.
+	MinArgs int
+	// MaxArgs is the maximum number of arguments. -1 means that the function is variadic.
+	MaxArgs int
+	// Since is the Terraform version that introduced the function. nil means it is available since v0.12.
+	Since *version.Version
+}
+
+func fixed(n int) Function {
+	return Function{MinArgs: n, MaxArgs: n}
+}
+
+func variadic(min int) Function {
+	return Function{MinArgs: min, MaxArgs: -1}
+}
+
+func since(v string, f Function) Function {
+	f.Since = version.Must(version.NewVersion(v))
+	return f
+}
+
+// Functions is the table of built-in functions available in Terraform v0.12 and later.
+// Functions removed in later versions, such as list() and map(), are also included.
+// See https://developer.hashicorp.com/terraform/language/functions
+var Functions = map[string]Function{
+	// Numeric functions
+	"abs":      fixed(1),
+	"ceil":     fixed(1),
+	"floor":    fixed(1),
+	"log":      fixed(2),
+	"max":      variadic(0),
+	"min":      variadic(0),
+	"parseint": fixed(2),
+	"pow":      fixed(2),
+	"signum":   fixed(1),
+
+	// String functions
+	"chomp":          fixed(1),
+	"endswith":       since("1.3.0", fixed(2)),
+	"format":         variadic(1),
+	"formatlist":     variadic(1),
+	"indent":         fixed(2),
+	"join":           variadic(1),
+	"lower":          fixed(1),
+	"regex":          fixed(2),
+	"regexall":       fixed(2),
+	"replace":        fixed(3),
+	"split":          fixed(2),
+	"startswith":     since("1.3.0", fixed(2)),
+	"strcontains":    since("1.5.0", fixed(2)),
+	"strrev":         fixed(1),
+	"substr":         fixed(3),
+	"templatestring": since("1.9.0", fixed(2)),
+	"title":          fixed(1),
+	"trim":           fixed(2),
+	"trimprefix":     fixed(2),
+	"trimspace":      fixed(1),
+	"trimsuffix":     fixed(2),
+	"upper":          fixed(1),
+
+	// Collection functions
+	"alltrue":         since("0.14.0", fixed(1)),
+	"anytrue":         since("0.14.0", fixed(1)),
+	"chunklist":       fixed(2),
+	"coalesce":        variadic(0),
+	"coalescelist":    variadic(0),
+	"compact":         fixed(1),
+	"concat":          variadic(0),
+	"contains":        fixed(2),
+	"distinct":        fixed(1),
+	"element":         fixed(2),
+	"flatten":         fixed(1),
+	"index":           fixed(2),
+	"keys":            fixed(1),
+	"length":          fixed(1),
+	"list":            variadic(0),
+	"lookup":          {MinArgs: 2, MaxArgs: 3},
+	"map":             variadic(0),
+	"matchkeys":       fixed(3),
+	"merge":           variadic(0),
+	"one":             since("0.15.0", fixed(1)),
+	"range":           {MinArgs: 1, MaxArgs: 3},
+	"reverse":         fixed(1),
+	"setintersection": variadic(1),
+	"setproduct":      variadic(0),
+	"setsubtract":     fixed(2),
+	"setunion":        variadic(1),
+	"slice":           fixed(3),
+	"sort":            fixed(1),
+	"sum":             since("0.13.0", fixed(1)),
+	"transpose":       fixed(1),
+	"values":          fixed(1),
+	"zipmap":          fixed(2),
+
+	// Encoding functions
+	"base64decode":     fixed(1),
+	"base64encode":     fixed(1),
+	"base64gzip":       fixed(1),
+	"csvdecode":        fixed(1),
+	"jsondecode":       fixed(1),
+	"jsonencode":       fixed(1),
+	"textdecodebase64": since("0.14.0", fixed(2)),
+	"textencodebase64": since("0.14.0", fixed(2)),
+	"urlencode":        fixed(1),
+	"yamldecode":       fixed(1),
+	"yamlencode":       fixed(1),
+
+	// Filesystem functions
+	"abspath":      fixed(1),
+	"basename":     fixed(1),
+	"dirname":      fixed(1),
+	"file":         fixed(1),
+	"filebase64":   fixed(1),
+	"fileexists":   fixed(1),
+	"fileset":      fixed(2),
+	"pathexpand":   fixed(1),
+	"templatefile": fixed(2),
+
+	// Date and time functions
+	"formatdate":    fixed(2),
+	"plantimestamp": since("1.5.0", fixed(0)),
+	"timeadd":       fixed(2),
+	"timecmp":       since("1.3.0", fixed(2)),
+	"timestamp":     fixed(0),
+
+	// Hash and crypto functions
+	"base64sha256":     fixed(1),
+	"base64sha512":     fixed(1),
+	"bcrypt":           {MinArgs: 1, MaxArgs: 2},
+	"filebase64sha256": fixed(1),
+	"filebase64sha512": fixed(1),
+	"filemd5":          fixed(1),
+	"filesha1":         fixed(1),
+	"filesha256":       fixed(1),
+	"filesha512":       fixed(1),
+	"md5":              fixed(1),
+	"rsadecrypt":       fixed(2),
+	"sha1":             fixed(1),
+	"sha256":           fixed(1),
+	"sha512":           fixed(1),
+	"uuid":             fixed(0),
+	"uuidv5":           fixed(2),
+
+	// IP network functions
+	"cidrhost":    fixed(2),
+	"cidrnetmask": fixed(1),
+	"cidrsubnet":  fixed(3),
+	"cidrsubnets": variadic(1),
+
+	// Type conversion functions
+	"can":             fixed(1),
+	"ephemeralasnull": since("1.10.0", fixed(1)),
+	"issensitive":     since("1.8.0", fixed(1)),
+	"nonsensitive":    since("0.15.0", fixed(1)),
+	"sensitive":       since("0.15.0", fixed(1)),
+	"tobool":          fixed(1),
+	"tolist":          fixed(1),
+	"tomap":           fixed(1),
+	"tonumber":        fixed(1),
+	"toset":           fixed(1),
+	"tostring":        fixed(1),
+	"try":             variadic(0),
+}
@@ -0,0 +1,122 @@
+package terraform
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/hashicorp/hcl/v2"
+)
+
+// JSONValue is a JSON value parsed from the token stream with source ranges.
+// Unlike encoding/json, all properties of an object are kept even if their names are duplicated.
+type JSONValue struct {
+	// Properties is the properties of an object in source order. It is nil if the value is not an object.
+	Properties []*JSONProperty
+	// Elements is the elements of an array. It is nil if the value is not an array.
+	Elements []*JSONValue
+}
+
+// JSONProperty is a property of a JSON object.
+type JSONProperty struct {
+	Name      string
+	NameRange hcl.Range
+	Value     *JSONValue
+}
+
+// ParseJSON parses the given JSON source into a JSONValue.
+func ParseJSON(src []byte, filename string) (*JSONValue, error) {
+	p := &jsonParser{
+		src:      src,
+		filename: filename,
+		dec:      json.NewDecoder(bytes.NewReader(src)),
+	}
+	p.dec.UseNumber()
+	return p.parseValue()
+}
+
+type jsonParser struct {
+	src      []byte
+	filename string
+	dec      *json.Decoder
+}
+
+func (p *jsonParser) parseValue() (*JSONValue, error) {
+	tok, err := p.dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	delim, ok := tok.(json.Delim)
+	if !ok {
+		// Scalar values have no properties to track.
+		return &JSONValue{}, nil
+	}
+
+	switch delim {
+	case '{':
+		value := &JSONValue{Properties: []*JSONProperty{}}
+		for p.dec.More() {
+			tok, err := p.dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			name, ok := tok.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected token %v in object", tok)
+			}
+			nameRange := p.stringRange(int(p.dec.InputOffset()))
+
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			value.Properties = append(value.Properties, &JSONProperty{Name: name, NameRange: nameRange, Value: v})
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+		return value, nil
+	case '[':
+		value := &JSONValue{Elements: []*JSONValue{}}
+		for p.dec.More() {
+			v, err := p.parseValue()
+			if err != nil {
+				return nil, err
+			}
+			value.Elements = append(value.Elements, v)
+		}
+		if _, err := p.dec.Token(); err != nil {
+			return nil, err
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unexpected delimiter %v", delim)
+	}
+}
+
+// stringRange returns the range of the string token that ends at the given offset, including the quotes.
+func (p *jsonParser) stringRange(end int) hcl.Range {
+	start := end - 2
+	for ; start > 0; start-- {
+		if p.src[start] != '"' {
+			continue
+		}
+		backslashes := 0
+		for i := start - 1; i >= 0 && p.src[i] == '\\'; i-- {
+			backslashes++
+		}
+		if backslashes%2 == 0 {
+			break
+		}
+	}
+
+	return hcl.Range{Filename: p.filename, Start: p.pos(start), End: p.pos(end)}
+}
+
+func (p *jsonParser) pos(offset int) hcl.Pos {
+	line := 1 + bytes.Count(p.src[:offset], []byte("\n"))
+	lineStart := bytes.LastIndexByte(p.src[:offset], '\n') + 1
+	return hcl.Pos{Line: line, Column: utf8.RuneCount(p.src[lineStart:offset]) + 1, Byte: offset}
+}
@@ -0,0 +1,54 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestParseJSON(t *testing.T) {
+	src := `{
+  "a": 1,
+  "b": {"c\"": [true, {"d": null}], "c\"": "x"},
+  "a": 2
+}`
+
+	got, err := ParseJSON([]byte(src), "main.tf.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type property struct {
+		Name  string
+		Range hcl.Range
+	}
+	var flatten func(v *JSONValue) []property
+	flatten = func(v *JSONValue) []property {
+		ret := []property{}
+		for _, prop := range v.Properties {
+			ret = append(ret, property{Name: prop.Name, Range: prop.NameRange})
+			ret = append(ret, flatten(prop.Value)...)
+		}
+		for _, elem := range v.Elements {
+			ret = append(ret, flatten(elem)...)
+		}
+		return ret
+	}
+
+	want := []property{
+		{Name: "a", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 2, Column: 3, Byte: 4}, End: hcl.Pos{Line: 2, Column: 6, Byte: 7}}},
+		{Name: "b", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 3, Byte: 14}, End: hcl.Pos{Line: 3, Column: 6, Byte: 17}}},
+		{Name: `c"`, Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 9, Byte: 20}, End: hcl.Pos{Line: 3, Column: 14, Byte: 25}}},
+		{Name: "d", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 24, Byte: 35}, End: hcl.Pos{Line: 3, Column: 27, Byte: 38}}},
+		{Name: `c"`, Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 37, Byte: 48}, End: hcl.Pos{Line: 3, Column: 42, Byte: 53}}},
+		{Name: "a", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 4, Column: 3, Byte: 63}, End: hcl.Pos{Line: 4, Column: 6, Byte: 66}}},
+	}
+	if diff := cmp.Diff(flatten(got), want); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, err := ParseJSON([]byte(`{"a": }`), "main.tf.json"); err == nil {
+		t.Error("expected an error for invalid JSON")
+	}
+}
@@ -0,0 +1,102 @@
+package terraform
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/go-version"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/gohcl"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	tfaddr "github.com/hashicorp/terraform-registry-address"
+)
+
+// LockFileName is the name of the dependency lock file in a module directory.
+const LockFileName = ".terraform.lock.hcl"
+
+// LockedProvider represents a "provider" block in the dependency lock file.
+type LockedProvider struct {
+	// Source is the fully-qualified source address, such as "registry.terraform.io/hashicorp/aws".
+	Source   string
+	Version  *version.Version
+	Hashes   []string
+	DefRange hcl.Range
+}
+
+// LoadLockFile reads the dependency lock file in the given directory and returns the locked providers
+// keyed by their fully-qualified source addresses. It returns nil if the file does not exist.
+//
+// @see https://github.com/hashicorp/terraform/blob/v1.9.0/internal/depsfile/locks_file.go
+func LoadLockFile(dir string) (map[string]*LockedProvider, hcl.Diagnostics) {
+	filename := filepath.Join(dir, LockFileName)
+	src, err := os.ReadFile(filename)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the dependency lock file",
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	file, diags := hclsyntax.ParseConfig(src, filename, hcl.InitialPos)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{{Type: "provider", LabelNames: []string{"source"}}},
+	})
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	providers := map[string]*LockedProvider{}
+	for _, block := range content.Blocks {
+		addr, err := tfaddr.ParseProviderSource(block.Labels[0])
+		if err != nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider source address",
+				Detail:   fmt.Sprintf("Cannot parse %q: %s", block.Labels[0], err),
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+
+		var decoded struct {
+			Version string   `hcl:"version"`
+			Hashes  []string `hcl:"hashes,optional"`
+			Remain  hcl.Body `hcl:",remain"`
+		}
+		if decodeDiags := gohcl.DecodeBody(block.Body, nil, &decoded); decodeDiags.HasErrors() {
+			diags = diags.Extend(decodeDiags)
+			continue
+		}
+		v, err := version.NewVersion(decoded.Version)
+		if err != nil {
+			diags = diags.Append(&hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Invalid provider version",
+				Detail:   fmt.Sprintf("Cannot parse %q: %s", decoded.Version, err),
+				Subject:  block.DefRange.Ptr(),
+			})
+			continue
+		}
+
+		providers[addr.String()] = &LockedProvider{
+			Source:   addr.String(),
+			Version:  v,
+			Hashes:   decoded.Hashes,
+			DefRange: block.DefRange,
+		}
+	}
+
+	return providers, diags
+}
@@ -0,0 +1,63 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLoadLockFile(t *testing.T) {
+	dir := t.TempDir()
+
+	got, diags := LoadLockFile(dir)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+	if got != nil {
+		t.Fatalf("got %v, want nil when the lock file does not exist", got)
+	}
+
+	lock := `
+provider "registry.terraform.io/hashicorp/aws" {
+  version     = "5.31.0"
+  constraints = "~> 5.0"
+  hashes = [
+    "h1:abc=",
+    "zh:def",
+  ]
+}
+
+provider "registry.terraform.io/hashicorp/random" {
+  version = "3.6.0"
+}
+`
+	if err := os.WriteFile(filepath.Join(dir, LockFileName), []byte(lock), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, diags = LoadLockFile(dir)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	versions := map[string]string{}
+	hashes := map[string][]string{}
+	for source, provider := range got {
+		versions[source] = provider.Version.String()
+		hashes[source] = provider.Hashes
+	}
+	if diff := cmp.Diff(versions, map[string]string{
+		"registry.terraform.io/hashicorp/aws":    "5.31.0",
+		"registry.terraform.io/hashicorp/random": "3.6.0",
+	}); diff != "" {
+		t.Error(diff)
+	}
+	if diff := cmp.Diff(hashes, map[string][]string{
+		"registry.terraform.io/hashicorp/aws":    {"h1:abc=", "zh:def"},
+		"registry.terraform.io/hashicorp/random": nil,
+	}); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,121 @@
+package terraform
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// moduleManifestPath is the path of the manifest "terraform init" writes for installed modules.
+var moduleManifestPath = filepath.Join(".terraform", "modules", "modules.json")
+
+// ModuleOutput represents an "output" block in a called module.
+type ModuleOutput struct {
+	Name      string
+	Sensitive bool
+	DefRange  hcl.Range
+}
+
+// ModuleDir returns the directory of the module called by the given module call in the root module.
+// Local modules are resolved relative to the calling module, and other modules are looked up
+// in the manifest written by "terraform init". It returns false if the directory cannot be determined.
+func ModuleDir(call *ModuleCall) (string, bool) {
+	if !call.SourceKnown || call.Source == "" {
+		return "", false
+	}
+	dir := filepath.Dir(call.DefRange.Filename)
+
+	// @see https://developer.hashicorp.com/terraform/language/modules/sources#local-paths
+	if strings.HasPrefix(call.Source, "./") || strings.HasPrefix(call.Source, "../") {
+		return filepath.Join(dir, filepath.FromSlash(call.Source)), true
+	}
+
+	src, err := os.ReadFile(filepath.Join(dir, moduleManifestPath))
+	if err != nil {
+		return "", false
+	}
+	var manifest struct {
+		Modules []struct {
+			Key string `json:"Key"`
+			Dir string `json:"Dir"`
+		} `json:"Modules"`
+	}
+	if err := json.Unmarshal(src, &manifest); err != nil {
+		return "", false
+	}
+	for _, module := range manifest.Modules {
+		if module.Key == call.Name {
+			return filepath.Join(dir, filepath.FromSlash(module.Dir)), true
+		}
+	}
+	return "", false
+}
+
+// LoadModuleOutputs parses the configuration files in the given module directory and returns its outputs.
+// Outputs whose sensitivity cannot be determined statically are treated as not sensitive.
+func LoadModuleOutputs(dir string) (map[string]*ModuleOutput, hcl.Diagnostics) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "Failed to read the module directory",
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	parser := hclparse.NewParser()
+	outputs := map[string]*ModuleOutput{}
+	diags := hcl.Diagnostics{}
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		filename := filepath.Join(dir, entry.Name())
+
+		var file *hcl.File
+		var parseDiags hcl.Diagnostics
+		switch {
+		case strings.HasSuffix(filename, ".tf"):
+			file, parseDiags = parser.ParseHCLFile(filename)
+		case strings.HasSuffix(filename, ".tf.json"):
+			file, parseDiags = parser.ParseJSONFile(filename)
+		default:
+			continue
+		}
+		diags = diags.Extend(parseDiags)
+		if parseDiags.HasErrors() {
+			continue
+		}
+
+		content, _, contentDiags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "output", LabelNames: []string{"name"}}},
+		})
+		diags = diags.Extend(contentDiags)
+
+		for _, block := range content.Blocks {
+			output := &ModuleOutput{Name: block.Labels[0], DefRange: block.DefRange}
+
+			attrs, _, _ := block.Body.PartialContent(&hcl.BodySchema{
+				Attributes: []hcl.AttributeSchema{{Name: "sensitive"}},
+			})
+			if attr, exists := attrs.Attributes["sensitive"]; exists {
+				val, valDiags := attr.Expr.Value(nil)
+				if !valDiags.HasErrors() && val.IsKnown() && !val.IsNull() && val.Type() == cty.Bool {
+					output.Sensitive = val.True()
+				}
+			}
+
+			outputs[output.Name] = output
+		}
+	}
+
+	return outputs, diags
+}
@@ -0,0 +1,118 @@
+package terraform
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/hashicorp/hcl/v2"
+)
+
+func TestModuleDir(t *testing.T) {
+	dir := t.TempDir()
+	manifest := `{"Modules":[{"Key":"","Source":"","Dir":"."},{"Key":"db","Source":"registry.terraform.io/example/db/aws","Version":"1.0.0","Dir":".terraform/modules/db"}]}`
+	if err := os.MkdirAll(filepath.Join(dir, ".terraform", "modules"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, moduleManifestPath), []byte(manifest), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name string
+		call *ModuleCall
+		want string
+		ok   bool
+	}{
+		{
+			name: "local",
+			call: &ModuleCall{Name: "network", Source: "./modules/network", SourceKnown: true},
+			want: filepath.Join(dir, "modules", "network"),
+			ok:   true,
+		},
+		{
+			name: "parent",
+			call: &ModuleCall{Name: "network", Source: "../network", SourceKnown: true},
+			want: filepath.Join(filepath.Dir(dir), "network"),
+			ok:   true,
+		},
+		{
+			name: "installed",
+			call: &ModuleCall{Name: "db", Source: "example/db/aws", SourceKnown: true},
+			want: filepath.Join(dir, ".terraform", "modules", "db"),
+			ok:   true,
+		},
+		{
+			name: "not installed",
+			call: &ModuleCall{Name: "cache", Source: "example/cache/aws", SourceKnown: true},
+			ok:   false,
+		},
+		{
+			name: "unknown",
+			call: &ModuleCall{Name: "network"},
+			ok:   false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.call.DefRange = hcl.Range{Filename: filepath.Join(dir, "main.tf")}
+
+			got, ok := ModuleDir(tc.call)
+			if ok != tc.ok {
+				t.Fatalf("got ok=%t, want %t", ok, tc.ok)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestLoadModuleOutputs(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"outputs.tf": `
+output "password" {
+  value     = random_password.main.result
+  sensitive = true
+}
+
+output "username" {
+  value = "admin"
+}
+
+output "token" {
+  value     = var.token
+  sensitive = var.sensitive
+}`,
+		"outputs.tf.json": `{"output": {"secret": {"value": "${var.secret}", "sensitive": true}}}`,
+		"README.md":       `output "ignored" {}`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	got, diags := LoadModuleOutputs(dir)
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	want := map[string]*ModuleOutput{
+		"password": {Name: "password", Sensitive: true},
+		"username": {Name: "username"},
+		"token":    {Name: "token"},
+		"secret":   {Name: "secret", Sensitive: true},
+	}
+	if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(ModuleOutput{}, "DefRange")); diff != "" {
+		t.Error(diff)
+	}
+
+	if _, diags := LoadModuleOutputs(filepath.Join(dir, "missing")); !diags.HasErrors() {
+		t.Error("expected an error for a missing directory")
+	}
+}
@@ -109,5 +109,7 @@ func (r *RuleSet) ApplyConfig(body *hclext.BodyContent) error {
 
 // NewRunner injects a custom runner
 func (r *RuleSet) NewRunner(runner tflint.Runner) (tflint.Runner, error) {
-	return NewRunner(runner), nil
+	custom := NewRunner(runner)
+	custom.Config = r.rulesetConfig
+	return custom, nil
 }
@@ -3,6 +3,7 @@ package terraform
 import (
 	"strings"
 
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/hcl/v2/hclsyntax"
 	"github.com/hashicorp/hcl/v2/json"
@@ -14,6 +15,9 @@ import (
 // Runner is a custom runner that provides helper functions for this ruleset.
 type Runner struct {
 	tflint.Runner
+
+	// Config is the ruleset config declared in the "plugin" block. It may be nil.
+	Config *Config
 }
 
 // NewRunner returns a new custom runner.
@@ -107,6 +111,169 @@ func (r *Runner) GetLocals() (map[string]*Local, hcl.Diagnostics) {
 	return locals, diags
 }
 
+// GetRequiredVersion returns the version constraints declared in "required_version" of all "terraform" blocks.
+// Terraform requires all of them to be satisfied, so they are combined into a single list.
+func (r *Runner) GetRequiredVersion() (version.Constraints, error) {
+	constraints := version.Constraints{}
+
+	body, err := r.GetModuleContent(&hclext.BodySchema{
+		Blocks: []hclext.BlockSchema{
+			{
+				Type: "terraform",
+				Body: &hclext.BodySchema{
+					Attributes: []hclext.AttributeSchema{{Name: "required_version"}},
+				},
+			},
+		},
+	}, &tflint.GetModuleContentOption{ExpandMode: tflint.ExpandModeNone})
+	if err != nil {
+		return constraints, err
+	}
+
+	for _, block := range body.Blocks {
+		attr, exists := block.Body.Attributes["required_version"]
+		if !exists {
+			continue
+		}
+		err := r.EvaluateExpr(attr.Expr, func(v string) error {
+			c, err := version.NewConstraint(v)
+			if err != nil {
+				return err
+			}
+			constraints = append(constraints, c...)
+			return nil
+		}, nil)
+		if err != nil {
+			return constraints, err
+		}
+	}
+
+	return constraints, nil
+}
+
+// GetRequiredProviders returns all entries in "required_providers" blocks of "terraform" blocks.
+// Entries are returned as attributes, so the local names that are not referenced by the module are also included.
+func (r *Runner) GetRequiredProviders() (hcl.Attributes, hcl.Diagnostics) {
+	requiredProviders := hcl.Attributes{}
+	diags := hcl.Diagnostics{}
+
+	files, err := r.GetFiles()
+	if err != nil {
+		return requiredProviders, hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "failed to call GetFiles()",
+				Detail:   err.Error(),
+			},
+		}
+	}
+
+	for _, file := range files {
+		content, _, schemaDiags := file.Body.PartialContent(&hcl.BodySchema{
+			Blocks: []hcl.BlockHeaderSchema{{Type: "terraform"}},
+		})
+		diags = diags.Extend(schemaDiags)
+		if schemaDiags.HasErrors() {
+			continue
+		}
+
+		for _, block := range content.Blocks {
+			content, _, schemaDiags := block.Body.PartialContent(&hcl.BodySchema{
+				Blocks: []hcl.BlockHeaderSchema{{Type: "required_providers"}},
+			})
+			diags = diags.Extend(schemaDiags)
+			if schemaDiags.HasErrors() {
+				continue
+			}
+
+			for _, block := range content.Blocks {
+				attributes, attrDiags := block.Body.JustAttributes()
+				diags = diags.Extend(attrDiags)
+				for name, attr := range attributes {
+					requiredProviders[name] = attr
+				}
+			}
+		}
+	}
+
+	return requiredProviders, diags
+}
+
+// GetObjectKeys returns the keys of the object that the expression statically builds.
+// References to the given local values and merge() calls are resolved, so the keys of
+// merge(local.tags, { Name = "web" }) can be determined. It returns false if any key
+// cannot be determined without evaluating the expression.
+func (r *Runner) GetObjectKeys(expr hcl.Expression, locals map[string]*Local) ([]string, bool) {
+	return r.getObjectKeys(expr, locals, map[string]bool{})
+}
+
+func (r *Runner) getObjectKeys(expr hcl.Expression, locals map[string]*Local, seen map[string]bool) ([]string, bool) {
+	if json.IsJSONExpression(expr) {
+		if pairs, diags := hcl.ExprMap(expr); !diags.HasErrors() {
+			return objectKeysInPairs(pairs)
+		}
+
+		// A JSON string like "${merge(local.tags, {})}" is parsed as a template.
+		nodes, diags := r.walkableNodesInExpr(expr)
+		if diags.HasErrors() || len(nodes) != 1 {
+			return nil, false
+		}
+		wrap, ok := nodes[0].(*hclsyntax.TemplateWrapExpr)
+		if !ok {
+			return nil, false
+		}
+		expr = wrap.Wrapped
+	}
+
+	switch e := expr.(type) {
+	case *hclsyntax.ObjectConsExpr:
+		pairs, _ := hcl.ExprMap(e)
+		return objectKeysInPairs(pairs)
+	case *hclsyntax.ScopeTraversalExpr:
+		if len(e.Traversal) < 2 || e.Traversal.RootName() != "local" {
+			return nil, false
+		}
+		attr, ok := e.Traversal[1].(hcl.TraverseAttr)
+		if !ok || len(e.Traversal) > 2 || seen[attr.Name] {
+			return nil, false
+		}
+		local, exists := locals[attr.Name]
+		if !exists {
+			return nil, false
+		}
+		seen[attr.Name] = true
+		defer delete(seen, attr.Name)
+		return r.getObjectKeys(local.Attribute.Expr, locals, seen)
+	case *hclsyntax.FunctionCallExpr:
+		if e.Name != "merge" || e.ExpandFinal {
+			return nil, false
+		}
+		keys := []string{}
+		for _, arg := range e.Args {
+			argKeys, ok := r.getObjectKeys(arg, locals, seen)
+			if !ok {
+				return nil, false
+			}
+			keys = append(keys, argKeys...)
+		}
+		return keys, true
+	}
+
+	return nil, false
+}
+
+func objectKeysInPairs(pairs []hcl.KeyValuePair) ([]string, bool) {
+	keys := make([]string, len(pairs))
+	for i, pair := range pairs {
+		key, diags := pair.Key.Value(nil)
+		if diags.HasErrors() || !key.IsKnown() || key.IsNull() || key.Type() != cty.String {
+			return nil, false
+		}
+		keys[i] = key.AsString()
+	}
+	return keys, true
+}
+
 // GetProviderRefs returns all references to providers in resources, data, provider declarations, module calls, and provider-defined functinos.
 func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 	providerRefs := map[string]*ProviderRef{}
@@ -183,7 +350,11 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 		}
 	}
 
-	var diags hcl.Diagnostics
+	requiredProviders, diags := r.GetRequiredProviders()
+	if diags.HasErrors() {
+		return providerRefs, diags
+	}
+
 	for _, block := range body.Blocks {
 		switch block.Type {
 		case "resource", "ephemeral", "data":
@@ -195,10 +366,7 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 				}
 				providerRefs[ref.Name] = ref
 			} else {
-				providerName := block.Labels[0]
-				if under := strings.Index(providerName, "_"); under != -1 {
-					providerName = providerName[:under]
-				}
+				providerName := r.impliedProviderName(block.Labels[0], requiredProviders)
 				providerRefs[providerName] = &ProviderRef{
 					Name:     providerName,
 					DefRange: block.DefRange,
@@ -236,10 +404,7 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 					}
 					providerRefs[ref.Name] = ref
 				} else {
-					providerName := data.Labels[0]
-					if under := strings.Index(providerName, "_"); under != -1 {
-						providerName = providerName[:under]
-					}
+					providerName := r.impliedProviderName(data.Labels[0], requiredProviders)
 					providerRefs[providerName] = &ProviderRef{
 						Name:     providerName,
 						DefRange: data.DefRange,
@@ -251,7 +416,78 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 		}
 	}
 
-	walkDiags := r.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
+	walkDiags := r.WalkFunctionCalls(func(funcCallExpr *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+		parts := strings.Split(funcCallExpr.Name, "::")
+		if len(parts) < 2 || parts[0] != "provider" || parts[1] == "" {
+			return nil
+		}
+		providerRefs[parts[1]] = &ProviderRef{
+			Name:     parts[1],
+			DefRange: funcCallExpr.Range(),
+		}
+		return nil
+	})
+	diags = diags.Extend(walkDiags)
+	if walkDiags.HasErrors() {
+		return providerRefs, diags
+	}
+
+	return providerRefs, diags
+}
+
+// impliedProviderName returns the local name of the provider that owns the resource type
+// when the resource does not set the "provider" meta-argument.
+//
+// Terraform takes the type prefix before the first underscore, but that is wrong for providers whose local names differ.
+// The longest prefix in the "provider_prefixes" config takes precedence. Otherwise, the longest local name in
+// required_providers that the type starts with is used, before falling back to Terraform's rule.
+//
+// @see https://github.com/hashicorp/terraform/blob/v1.9.0/internal/addrs/resource.go#L97-L108
+func (r *Runner) impliedProviderName(resourceType string, requiredProviders hcl.Attributes) string {
+	hasPrefix := func(prefix string) bool {
+		return resourceType == prefix || strings.HasPrefix(resourceType, prefix+"_")
+	}
+
+	if r.Config != nil {
+		var longest string
+		for prefix := range r.Config.ProviderPrefixes {
+			if hasPrefix(prefix) && len(prefix) > len(longest) {
+				longest = prefix
+			}
+		}
+		if longest != "" {
+			return r.Config.ProviderPrefixes[longest]
+		}
+	}
+
+	var longest string
+	for name := range requiredProviders {
+		if hasPrefix(name) && len(name) > len(longest) {
+			longest = name
+		}
+	}
+	if longest != "" {
+		return longest
+	}
+
+	if under := strings.Index(resourceType, "_"); under != -1 {
+		return resourceType[:under]
+	}
+	return resourceType
+}
+
+// WalkFunctionCalls walks all function calls in the module, including calls in JSON syntax.
+// Each call is passed to the walker exactly once, even if it is nested in other expressions.
+func (r *Runner) WalkFunctionCalls(walker func(call *hclsyntax.FunctionCallExpr) hcl.Diagnostics) hcl.Diagnostics {
+	return r.WalkExpressions(tflint.ExprWalkFunc(func(expr hcl.Expression) hcl.Diagnostics {
+		// Native expressions are walked recursively, so only the expression itself is checked.
+		if funcCallExpr, ok := expr.(*hclsyntax.FunctionCallExpr); ok {
+			return walker(funcCallExpr)
+		}
+		if !json.IsJSONExpression(expr) {
+			return nil
+		}
+
 		// For JSON syntax, walker is not implemented,
 		// so extract the hclsyntax.Node that we can walk on.
 		// See https://github.com/hashicorp/hcl/issues/543
@@ -260,14 +496,7 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 		for _, node := range nodes {
 			visitDiags := hclsyntax.VisitAll(node, func(n hclsyntax.Node) hcl.Diagnostics {
 				if funcCallExpr, ok := n.(*hclsyntax.FunctionCallExpr); ok {
-					parts := strings.Split(funcCallExpr.Name, "::")
-					if len(parts) < 2 || parts[0] != "provider" || parts[1] == "" {
-						return nil
-					}
-					providerRefs[parts[1]] = &ProviderRef{
-						Name:     parts[1],
-						DefRange: funcCallExpr.Range(),
-					}
+					return walker(funcCallExpr)
 				}
 				return nil
 			})
@@ -275,12 +504,87 @@ func (r *Runner) GetProviderRefs() (map[string]*ProviderRef, hcl.Diagnostics) {
 		}
 		return diags
 	}))
-	diags = diags.Extend(walkDiags)
-	if walkDiags.HasErrors() {
-		return providerRefs, diags
+}
+
+// WalkAttributes walks all arguments in the module, including arguments in nested blocks
+// and attributes of object expressions, and calls the walker with the name and the value.
+//
+// For JSON syntax, blocks cannot be distinguished from objects, so every object property is
+// passed to the walker. Values are passed as hclsyntax expressions parsed from JSON strings,
+// so values that are not strings, such as numbers and booleans, are not passed.
+func (r *Runner) WalkAttributes(walker func(name string, expr hclsyntax.Expression) hcl.Diagnostics) hcl.Diagnostics {
+	files, err := r.GetFiles()
+	if err != nil {
+		return hcl.Diagnostics{
+			{
+				Severity: hcl.DiagError,
+				Summary:  "failed to call GetFiles()",
+				Detail:   err.Error(),
+			},
+		}
 	}
 
-	return providerRefs, diags
+	diags := hcl.Diagnostics{}
+	for _, file := range files {
+		if body, ok := file.Body.(*hclsyntax.Body); ok {
+			visitDiags := hclsyntax.VisitAll(body, func(n hclsyntax.Node) hcl.Diagnostics {
+				switch node := n.(type) {
+				case *hclsyntax.Attribute:
+					return walker(node.Name, node.Expr)
+				case *hclsyntax.ObjectConsExpr:
+					var diags hcl.Diagnostics
+					for _, item := range node.Items {
+						key, keyDiags := item.KeyExpr.Value(nil)
+						if keyDiags.HasErrors() || !key.IsKnown() || key.IsNull() || key.Type() != cty.String {
+							continue
+						}
+						diags = diags.Extend(walker(key.AsString(), item.ValueExpr))
+					}
+					return diags
+				}
+				return nil
+			})
+			diags = diags.Extend(visitDiags)
+			continue
+		}
+
+		// In JSON syntax, everything can be walked as an attribute.
+		attrs, jsonDiags := file.Body.JustAttributes()
+		diags = diags.Extend(jsonDiags)
+		if jsonDiags.HasErrors() {
+			continue
+		}
+		for name, attr := range attrs {
+			diags = diags.Extend(r.walkJSONAttribute(name, attr.Expr, walker))
+		}
+	}
+
+	return diags
+}
+
+func (r *Runner) walkJSONAttribute(name string, expr hcl.Expression, walker func(name string, expr hclsyntax.Expression) hcl.Diagnostics) hcl.Diagnostics {
+	if pairs, diags := hcl.ExprMap(expr); !diags.HasErrors() {
+		for _, pair := range pairs {
+			key, keyDiags := pair.Key.Value(nil)
+			if keyDiags.HasErrors() || key.Type() != cty.String {
+				continue
+			}
+			diags = diags.Extend(r.walkJSONAttribute(key.AsString(), pair.Value, walker))
+		}
+		return diags
+	}
+	if exprs, diags := hcl.ExprList(expr); !diags.HasErrors() {
+		for _, expr := range exprs {
+			diags = diags.Extend(r.walkJSONAttribute(name, expr, walker))
+		}
+		return diags
+	}
+
+	nodes, diags := r.walkableNodesInExpr(expr)
+	for _, node := range nodes {
+		diags = diags.Extend(walker(name, node.(hclsyntax.Expression)))
+	}
+	return diags
 }
 
 // walkableNodesInExpr returns hclsyntax.Node from the given expression.
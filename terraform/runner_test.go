@@ -1,6 +1,7 @@
 package terraform
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -387,6 +388,7 @@ func TestGetProviderRefs(t *testing.T) {
 		name    string
 		json    bool
 		content string
+		config  *Config
 		want    map[string]*ProviderRef
 	}{
 		{
@@ -494,6 +496,44 @@ output "foo" {
 				"time": {Name: "time", DefRange: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 15}, End: hcl.Pos{Line: 3, Column: 68}}},
 			},
 		},
+		{
+			name: "resource with required_providers local name",
+			content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source = "corp/cloud"
+    }
+  }
+}
+
+resource "corp_cloud_instance" "main" {}
+resource "corp_instance" "main" {}`,
+			want: map[string]*ProviderRef{
+				"corp_cloud": {Name: "corp_cloud", DefRange: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 10, Column: 1}, End: hcl.Pos{Line: 10, Column: 38}}},
+				"corp":       {Name: "corp", DefRange: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 11, Column: 1}, End: hcl.Pos{Line: 11, Column: 32}}},
+			},
+		},
+		{
+			name: "resource with provider prefixes",
+			content: `
+terraform {
+  required_providers {
+    corp_cloud = {
+      source = "corp/cloud"
+    }
+  }
+}
+
+resource "corp_cloud_instance" "main" {}
+data "mycorp_network" "main" {}`,
+			config: &Config{
+				ProviderPrefixes: map[string]string{"corp": "corp-cloud", "mycorp": "corp-cloud"},
+			},
+			want: map[string]*ProviderRef{
+				"corp-cloud": {Name: "corp-cloud", DefRange: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 11, Column: 1}, End: hcl.Pos{Line: 11, Column: 29}}},
+			},
+		},
 	}
 
 	for _, test := range tests {
@@ -503,6 +543,7 @@ output "foo" {
 				filename += ".json"
 			}
 			runner := NewRunner(helper.TestRunner(t, map[string]string{filename: test.content}))
+			runner.Config = test.config
 
 			got, diags := runner.GetProviderRefs()
 			if diags.HasErrors() {
@@ -518,3 +559,339 @@ output "foo" {
 		})
 	}
 }
+
+func TestWalkAttributes(t *testing.T) {
+	type attribute struct {
+		Name  string
+		Range hcl.Range
+	}
+
+	tests := []struct {
+		name    string
+		json    bool
+		content string
+		want    []attribute
+	}{
+		{
+			name: "HCL",
+			content: `
+resource "aws_db_instance" "main" {
+  password = "foo"
+  tags = {
+    Name = "bar"
+  }
+
+  restore_to_point_in_time {
+    source_db_instance_identifier = "baz"
+  }
+}`,
+			want: []attribute{
+				{Name: "password", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 14}, End: hcl.Pos{Line: 3, Column: 19}}},
+				{Name: "tags", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4, Column: 10}, End: hcl.Pos{Line: 6, Column: 4}}},
+				{Name: "Name", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 5, Column: 12}, End: hcl.Pos{Line: 5, Column: 17}}},
+				{Name: "source_db_instance_identifier", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 9, Column: 37}, End: hcl.Pos{Line: 9, Column: 42}}},
+			},
+		},
+		{
+			name: "JSON",
+			json: true,
+			content: `
+{
+  "resource": {
+    "aws_db_instance": {
+      "main": {
+        "password": "foo",
+        "allocated_storage": 10,
+        "vpc_security_group_ids": ["${var.sg}"]
+      }
+    }
+  }
+}`,
+			want: []attribute{
+				{Name: "password", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 6, Column: 21}, End: hcl.Pos{Line: 6, Column: 24}}},
+				{Name: "vpc_security_group_ids", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 8, Column: 36}, End: hcl.Pos{Line: 8, Column: 45}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := "main.tf"
+			if test.json {
+				filename += ".json"
+			}
+			runner := NewRunner(helper.TestRunner(t, map[string]string{filename: test.content}))
+
+			got := []attribute{}
+			diags := runner.WalkAttributes(func(name string, expr hclsyntax.Expression) hcl.Diagnostics {
+				got = append(got, attribute{Name: name, Range: expr.Range()})
+				return nil
+			})
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+				cmpopts.SortSlices(func(a, b attribute) bool { return a.Name < b.Name }),
+			}
+			if diff := cmp.Diff(got, test.want, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWalkFunctionCalls(t *testing.T) {
+	type call struct {
+		Name  string
+		Range hcl.Range
+	}
+
+	tests := []struct {
+		name    string
+		json    bool
+		content string
+		want    []call
+	}{
+		{
+			name: "HCL",
+			content: `
+locals {
+  foo = upper(lower("FOO"))
+  bar = "${length(var.bar)}"
+}`,
+			want: []call{
+				{Name: "length", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 4, Column: 12}, End: hcl.Pos{Line: 4, Column: 27}}},
+				{Name: "lower", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 15}, End: hcl.Pos{Line: 3, Column: 27}}},
+				{Name: "upper", Range: hcl.Range{Filename: "main.tf", Start: hcl.Pos{Line: 3, Column: 9}, End: hcl.Pos{Line: 3, Column: 28}}},
+			},
+		},
+		{
+			name: "JSON",
+			json: true,
+			content: `
+{
+  "locals": {
+    "foo": "${upper(lower(\"FOO\"))}"
+  }
+}`,
+			want: []call{
+				{Name: "lower", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 21}, End: hcl.Pos{Line: 3, Column: 33}}},
+				{Name: "upper", Range: hcl.Range{Filename: "main.tf.json", Start: hcl.Pos{Line: 3, Column: 15}, End: hcl.Pos{Line: 3, Column: 34}}},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filename := "main.tf"
+			if test.json {
+				filename += ".json"
+			}
+			runner := NewRunner(helper.TestRunner(t, map[string]string{filename: test.content}))
+
+			got := []call{}
+			diags := runner.WalkFunctionCalls(func(expr *hclsyntax.FunctionCallExpr) hcl.Diagnostics {
+				got = append(got, call{Name: expr.Name, Range: expr.Range()})
+				return nil
+			})
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+
+			opts := []cmp.Option{
+				cmpopts.IgnoreFields(hcl.Pos{}, "Byte"),
+				cmpopts.SortSlices(func(a, b call) bool { return a.Name < b.Name }),
+			}
+			if diff := cmp.Diff(got, test.want, opts...); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGetRequiredVersion(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+	}{
+		{
+			name:  "no terraform block",
+			files: map[string]string{"main.tf": `locals {}`},
+			want:  []string{},
+		},
+		{
+			name: "single constraint",
+			files: map[string]string{"main.tf": `
+terraform {
+  required_version = ">= 1.3, < 2.0"
+}`},
+			want: []string{">= 1.3", "< 2.0"},
+		},
+		{
+			name: "multiple blocks",
+			files: map[string]string{
+				"main.tf": `
+terraform {
+  required_version = ">= 1.3"
+}`,
+				"versions.tf": `
+terraform {
+  required_version = "< 2.0"
+}`,
+			},
+			want: []string{">= 1.3", "< 2.0"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := NewRunner(helper.TestRunner(t, test.files))
+
+			got, err := runner.GetRequiredVersion()
+			if err != nil {
+				t.Fatal(err)
+			}
+			constraints := make([]string, len(got))
+			for i, c := range got {
+				constraints[i] = strings.TrimSpace(c.String())
+			}
+			opt := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+			if diff := cmp.Diff(constraints, test.want, opt); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGetObjectKeys(t *testing.T) {
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []string
+		ok    bool
+	}{
+		{
+			name: "object",
+			files: map[string]string{"main.tf": `
+resource "aws_instance" "main" {
+  tags = { Name = "web", "Owner" = var.owner }
+}`},
+			want: []string{"Name", "Owner"},
+			ok:   true,
+		},
+		{
+			name: "merged locals",
+			files: map[string]string{"main.tf": `
+locals {
+  common = { Owner = "platform" }
+  tags   = merge(local.common, { Environment = var.env })
+}
+
+resource "aws_instance" "main" {
+  tags = merge(local.tags, { Name = "web" })
+}`},
+			want: []string{"Owner", "Environment", "Name"},
+			ok:   true,
+		},
+		{
+			name: "unknown keys",
+			files: map[string]string{"main.tf": `
+resource "aws_instance" "main" {
+  tags = merge(var.tags, { Name = "web" })
+}`},
+			ok: false,
+		},
+		{
+			name: "computed key",
+			files: map[string]string{"main.tf": `
+resource "aws_instance" "main" {
+  tags = { (var.key) = "web" }
+}`},
+			ok: false,
+		},
+		{
+			name: "JSON",
+			files: map[string]string{"main.tf.json": `
+{
+  "locals": {
+    "common": { "Owner": "platform" }
+  },
+  "resource": {
+    "aws_instance": {
+      "main": {
+        "tags": "${merge(local.common, { Name = \"web\" })}"
+      }
+    }
+  }
+}`},
+			want: []string{"Owner", "Name"},
+			ok:   true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			runner := NewRunner(helper.TestRunner(t, test.files))
+
+			locals, diags := runner.GetLocals()
+			if diags.HasErrors() {
+				t.Fatal(diags)
+			}
+			body, err := runner.GetResourceContent("aws_instance", &hclext.BodySchema{
+				Attributes: []hclext.AttributeSchema{{Name: "tags"}},
+			}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, ok := runner.GetObjectKeys(body.Blocks[0].Body.Attributes["tags"].Expr, locals)
+			if ok != test.ok {
+				t.Fatalf("got ok=%t, want %t", ok, test.ok)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestGetRequiredProviders(t *testing.T) {
+	files := map[string]string{
+		"main.tf": `
+terraform {
+  required_providers {
+    aws = {
+      source  = "hashicorp/aws"
+      version = "~> 5.0"
+    }
+  }
+}`,
+		"versions.tf.json": `
+{
+  "terraform": {
+    "required_providers": {
+      "google": { "source": "hashicorp/google" }
+    }
+  }
+}`,
+	}
+
+	runner := NewRunner(helper.TestRunner(t, files))
+
+	got, diags := runner.GetRequiredProviders()
+	if diags.HasErrors() {
+		t.Fatal(diags)
+	}
+
+	names := []string{}
+	for name := range got {
+		names = append(names, name)
+	}
+	opt := cmpopts.SortSlices(func(a, b string) bool { return a < b })
+	if diff := cmp.Diff(names, []string{"aws", "google"}, opt); diff != "" {
+		t.Error(diff)
+	}
+}
@@ -115,6 +115,55 @@ type Local struct {
 	DefRange  hcl.Range
 }
 
+// RequiredProvider represents a single entry from a "required_providers" block.
+type RequiredProvider struct {
+	Name string
+	// Source is the source address. If the entry omits it, it is "hashicorp/<name>" as Terraform assumes.
+	Source string
+	// SourceExpr is the expression of "source", or nil if the entry omits it.
+	SourceExpr hcl.Expression
+	// VersionExpr is the expression of "version", or nil if the entry omits it.
+	// For legacy entries like `aws = "~> 5.0"`, it is the entire expression.
+	VersionExpr hcl.Expression
+	Attribute   *hcl.Attribute
+}
+
+// DecodeRequiredProvider decodes an entry from a "required_providers" block.
+// Other arguments such as "configuration_aliases" are ignored.
+func DecodeRequiredProvider(attr *hcl.Attribute) *RequiredProvider {
+	provider := &RequiredProvider{
+		Name:      attr.Name,
+		Source:    "hashicorp/" + attr.Name,
+		Attribute: attr,
+	}
+
+	pairs, diags := hcl.ExprMap(attr.Expr)
+	if diags.HasErrors() {
+		provider.VersionExpr = attr.Expr
+		return provider
+	}
+
+	for _, pair := range pairs {
+		key, diags := pair.Key.Value(nil)
+		if diags.HasErrors() || key.Type() != cty.String {
+			continue
+		}
+		switch key.AsString() {
+		case "source":
+			val, diags := pair.Value.Value(nil)
+			if diags.HasErrors() || !val.IsKnown() || val.IsNull() || val.Type() != cty.String {
+				continue
+			}
+			provider.Source = val.AsString()
+			provider.SourceExpr = pair.Value
+		case "version":
+			provider.VersionExpr = pair.Value
+		}
+	}
+
+	return provider
+}
+
 // ProviderRef represents a reference to a provider like `provider = google.europe` in a resource or module.
 type ProviderRef struct {
 	Name     string
@@ -123,7 +172,7 @@ type ProviderRef struct {
 
 // @see https://github.com/hashicorp/terraform/blob/v1.2.7/internal/configs/resource.go#L624-L695
 func decodeProviderRef(expr hcl.Expression, defRange hcl.Range) (*ProviderRef, hcl.Diagnostics) {
-	expr, diags := shimTraversalInString(expr)
+	expr, diags := ShimTraversalInString(expr)
 	if diags.HasErrors() {
 		return nil, diags
 	}
@@ -139,8 +188,11 @@ func decodeProviderRef(expr hcl.Expression, defRange hcl.Range) (*ProviderRef, h
 	}, nil
 }
 
+// ShimTraversalInString converts a string literal such as "aws_instance.foo" into a traversal expression
+// for backward compatibility with Terraform 0.11 and earlier. Other expressions are returned as is.
+//
 // @see https://github.com/hashicorp/terraform/blob/v1.2.5/internal/configs/compat_shim.go#L34
-func shimTraversalInString(expr hcl.Expression) (hcl.Expression, hcl.Diagnostics) {
+func ShimTraversalInString(expr hcl.Expression) (hcl.Expression, hcl.Diagnostics) {
 	// ObjectConsKeyExpr is a special wrapper type used for keys on object
 	// constructors to deal with the fact that naked identifiers are normally
 	// handled as "bareword" strings rather than as variable references. Since
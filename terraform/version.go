@@ -0,0 +1,113 @@
+package terraform
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/hashicorp/go-version"
+)
+
+// unboundedVersion is far above any real release. Constraints that allow it have no upper bound.
+var unboundedVersion = version.Must(version.NewVersion("999999.0.0"))
+
+// releasedMinorVersions are the minor versions of Terraform that support the HCL2 syntax.
+var releasedMinorVersions = []string{
+	"0.12", "0.13", "0.14", "0.15",
+	"1.0", "1.1", "1.2", "1.3", "1.4", "1.5", "1.6", "1.7", "1.8", "1.9", "1.10", "1.11", "1.12", "1.13", "1.14",
+}
+
+// maxPatchVersion is large enough to cover every patch release of a minor version.
+const maxPatchVersion = 30
+
+// constraintRegexp matches a single version constraint such as ">= 1.3" and "1.3.0"
+var constraintRegexp = regexp.MustCompile(`^\s*(=|!=|>=|<=|>|<|~>)?\s*(\S+)\s*$`)
+
+// MinimumVersion returns the lowest Terraform version that satisfies the given constraints.
+// It returns nil if there are no constraints or no released version satisfies them.
+func MinimumVersion(constraints version.Constraints) *version.Version {
+	if len(constraints) == 0 {
+		return nil
+	}
+
+	for _, minor := range releasedMinorVersions {
+		for patch := 0; patch <= maxPatchVersion; patch++ {
+			v := version.Must(version.NewVersion(fmt.Sprintf("%s.%d", minor, patch)))
+			if constraints.Check(v) {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// ConstraintOperator returns the operator of the constraint, such as ">=" and "~>".
+// A constraint without an operator requires an exact version, so "=" is returned for it.
+func ConstraintOperator(c *version.Constraint) string {
+	match := constraintRegexp.FindStringSubmatch(c.String())
+	if match == nil || match[1] == "" {
+		return "="
+	}
+	return match[1]
+}
+
+// ConstraintVersion returns the version that the constraint compares against.
+func ConstraintVersion(c *version.Constraint) *version.Version {
+	match := constraintRegexp.FindStringSubmatch(c.String())
+	if match == nil {
+		return nil
+	}
+	v, err := version.NewVersion(match[2])
+	if err != nil {
+		return nil
+	}
+	return v
+}
+
+// AllowsMajorUpgrade returns whether the constraints allow a version whose major version is
+// greater than the lowest version they allow. For example, ">= 4.0", "~> 4", and ">= 4.0, <= 5.0" allow 5.0.0,
+// while "~> 4.0" and ">= 4.0, < 5.0" don't. Constraints without a lower bound, such as "< 5.0",
+// only allow major upgrades if they have no upper bound either.
+func AllowsMajorUpgrade(constraints version.Constraints) bool {
+	lowest := LowestVersion(constraints)
+	if lowest == nil {
+		return false
+	}
+	if lowest.Equal(version.Must(version.NewVersion("0.0.0"))) {
+		return !HasUpperBound(constraints)
+	}
+
+	next := version.MustConstraints(version.NewConstraint(fmt.Sprintf(">= %d.0.0", lowest.Segments()[0]+1)))
+	return LowestVersion(append(slices.Clone(constraints), next...)) != nil
+}
+
+// HasUpperBound returns whether the constraints reject versions above some version.
+// For example, "< 5.0", "~> 4.0", and "4.2.0" have an upper bound, while ">= 4.0" and "~> 4" don't.
+func HasUpperBound(constraints version.Constraints) bool {
+	return !constraints.Check(unboundedVersion)
+}
+
+// LowestVersion returns the lowest version that satisfies the constraints, regardless of whether it is released.
+// It returns nil if no version satisfies them, such as ">= 1.5, < 1.3".
+// Prerelease versions are not considered.
+func LowestVersion(constraints version.Constraints) *version.Version {
+	// The lowest satisfying version is either the version of a constraint,
+	// the patch release following it, or 0.0.0 if there is no lower bound.
+	candidates := []*version.Version{version.Must(version.NewVersion("0.0.0"))}
+	for _, c := range constraints {
+		v := ConstraintVersion(c)
+		if v == nil {
+			continue
+		}
+		segments := v.Segments()
+		candidates = append(candidates, v, version.Must(version.NewVersion(fmt.Sprintf("%d.%d.%d", segments[0], segments[1], segments[2]+1))))
+	}
+
+	var lowest *version.Version
+	for _, candidate := range candidates {
+		if constraints.Check(candidate) && (lowest == nil || candidate.LessThan(lowest)) {
+			lowest = candidate
+		}
+	}
+	return lowest
+}
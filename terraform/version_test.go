@@ -0,0 +1,159 @@
+package terraform
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-version"
+)
+
+func TestMinimumVersion(t *testing.T) {
+	tests := []struct {
+		constraints string
+		want        string
+	}{
+		{constraints: "", want: ""},
+		{constraints: ">= 1.3", want: "1.3.0"},
+		{constraints: "> 1.3.0", want: "1.3.1"},
+		{constraints: "~> 0.14.5", want: "0.14.5"},
+		{constraints: ">= 0.11, < 0.12", want: ""},
+		{constraints: ">= 99.0", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraints, func(t *testing.T) {
+			constraints := version.Constraints{}
+			if test.constraints != "" {
+				constraints = version.MustConstraints(version.NewConstraint(test.constraints))
+			}
+
+			got := MinimumVersion(constraints)
+			if got == nil {
+				if test.want != "" {
+					t.Errorf("got nil, want %s", test.want)
+				}
+				return
+			}
+			if got.String() != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestConstraintOperator(t *testing.T) {
+	tests := []struct {
+		constraint string
+		operator   string
+		version    string
+	}{
+		{constraint: "1.3.0", operator: "=", version: "1.3.0"},
+		{constraint: "= 1.3.0", operator: "=", version: "1.3.0"},
+		{constraint: ">=1.3", operator: ">=", version: "1.3.0"},
+		{constraint: "~> 1.3", operator: "~>", version: "1.3.0"},
+		{constraint: "!= 1.4.0", operator: "!=", version: "1.4.0"},
+		{constraint: "< 2.0", operator: "<", version: "2.0.0"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraint, func(t *testing.T) {
+			c := version.MustConstraints(version.NewConstraint(test.constraint))[0]
+
+			if got := ConstraintOperator(c); got != test.operator {
+				t.Errorf("got operator %s, want %s", got, test.operator)
+			}
+			if got := ConstraintVersion(c); got.String() != test.version {
+				t.Errorf("got version %s, want %s", got, test.version)
+			}
+		})
+	}
+}
+
+func TestAllowsMajorUpgrade(t *testing.T) {
+	tests := []struct {
+		constraints string
+		want        bool
+	}{
+		{constraints: ">= 4.0", want: true},
+		{constraints: "> 4.0", want: true},
+		{constraints: "~> 4", want: true},
+		{constraints: ">= 4.0, != 5.0.0", want: true},
+		{constraints: ">= 4.0, <= 5.0", want: true},
+		{constraints: ">= 3.0, < 6.0", want: true},
+		{constraints: "!= 4.0.0", want: true},
+		{constraints: "< 5.0", want: false},
+		{constraints: "<= 4.9", want: false},
+		{constraints: "~> 4.0", want: false},
+		{constraints: "~> 0.14.5", want: false},
+		{constraints: ">= 4.2, < 5.0", want: false},
+		{constraints: "4.2.0", want: false},
+		{constraints: ">= 5.0, < 4.0", want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraints, func(t *testing.T) {
+			constraints := version.MustConstraints(version.NewConstraint(test.constraints))
+
+			if got := AllowsMajorUpgrade(constraints); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestHasUpperBound(t *testing.T) {
+	tests := []struct {
+		constraints string
+		want        bool
+	}{
+		{constraints: ">= 4.0", want: false},
+		{constraints: "~> 4", want: false},
+		{constraints: "!= 4.0.0", want: false},
+		{constraints: "< 5.0", want: true},
+		{constraints: "<= 4.9", want: true},
+		{constraints: "~> 4.0", want: true},
+		{constraints: ">= 3.0, < 6.0", want: true},
+		{constraints: "4.2.0", want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraints, func(t *testing.T) {
+			constraints := version.MustConstraints(version.NewConstraint(test.constraints))
+
+			if got := HasUpperBound(constraints); got != test.want {
+				t.Errorf("got %t, want %t", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLowestVersion(t *testing.T) {
+	tests := []struct {
+		constraints string
+		want        string
+	}{
+		{constraints: ">= 1.3", want: "1.3.0"},
+		{constraints: "> 1.3.0", want: "1.3.1"},
+		{constraints: "< 2.0", want: "0.0.0"},
+		{constraints: "~> 1.5.2", want: "1.5.2"},
+		{constraints: ">= 1.5, != 1.5.0", want: "1.5.1"},
+		{constraints: ">= 99.0", want: "99.0.0"},
+		{constraints: ">= 1.5, < 1.3", want: ""},
+		{constraints: "> 1.3.0, < 1.3.1", want: ""},
+		{constraints: "1.5.7, 1.6.0", want: ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.constraints, func(t *testing.T) {
+			got := LowestVersion(version.MustConstraints(version.NewConstraint(test.constraints)))
+			if got == nil {
+				if test.want != "" {
+					t.Errorf("got nil, want %s", test.want)
+				}
+				return
+			}
+			if got.String() != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}